@@ -3,6 +3,7 @@ package ethereum
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,23 +17,56 @@ import (
 	"github.com/rs/zerolog"
 )
 
+var (
+	// ErrInvalidSpec is returned when a spec value needed for slot arithmetic is zero
+	ErrInvalidSpec = errors.New("invalid beacon spec")
+	// ErrInvalidPeriodRange is returned when a period range ends before it starts
+	ErrInvalidPeriodRange = errors.New("invalid period range")
+)
+
+// MaxRequestLightClientUpdates is the maximum number of light client updates a beacon node serves per request,
+// MAX_REQUEST_LIGHT_CLIENT_UPDATES in the consensus specs
+const MaxRequestLightClientUpdates = 128
+
 type BeaconAPIClient struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	client eth2client.Service
-	url    string
+	client     eth2client.Service
+	httpClient *http.Client
+	url        string
 
 	Retries   int
 	RetryWait time.Duration
+	// LightClientUpdatesPerRequest caps the periods requested at once by GetLightClientUpdatesRange
+	LightClientUpdatesPerRequest uint64
 }
 
 func (b BeaconAPIClient) GetBeaconAPIURL() string {
 	return b.url
 }
 
-func (s Spec) Period() uint64 {
-	return s.EpochsPerSyncCommitteePeriod * s.SlotsPerEpoch
+// Period returns the number of slots in a sync committee period.
+// It errors if the spec is misconfigured so that callers never divide by zero.
+func (s Spec) Period() (uint64, error) {
+	if s.SlotsPerEpoch == 0 {
+		return 0, fmt.Errorf("%w: SLOTS_PER_EPOCH is zero", ErrInvalidSpec)
+	}
+	if s.EpochsPerSyncCommitteePeriod == 0 {
+		return 0, fmt.Errorf("%w: EPOCHS_PER_SYNC_COMMITTEE_PERIOD is zero", ErrInvalidSpec)
+	}
+
+	return s.EpochsPerSyncCommitteePeriod * s.SlotsPerEpoch, nil
+}
+
+// PeriodAtSlot returns the sync committee period the given slot belongs to
+func (s Spec) PeriodAtSlot(slot uint64) (uint64, error) {
+	period, err := s.Period()
+	if err != nil {
+		return 0, err
+	}
+
+	return slot / period, nil
 }
 
 func (b BeaconAPIClient) Close() {
@@ -40,12 +74,19 @@ func (b BeaconAPIClient) Close() {
 }
 
 func NewBeaconAPIClient(ctx context.Context, beaconAPIAddress string) (BeaconAPIClient, error) {
+	return NewBeaconAPIClientWithHTTPClient(ctx, beaconAPIAddress, http.DefaultClient)
+}
+
+// NewBeaconAPIClientWithHTTPClient is like NewBeaconAPIClient but sends all beacon API requests through
+// httpClient, e.g. one from NewTLSHTTPClient for a node behind a private CA.
+func NewBeaconAPIClientWithHTTPClient(ctx context.Context, beaconAPIAddress string, httpClient *http.Client) (BeaconAPIClient, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	client, err := ethttp.New(ctx,
 		// WithAddress supplies the address of the beacon node, as a URL.
 		ethttp.WithAddress(beaconAPIAddress),
 		// LogLevel supplies the level of logging to carry out.
 		ethttp.WithLogLevel(zerolog.WarnLevel),
+		ethttp.WithHTTPClient(httpClient),
 	)
 	if err != nil {
 		cancel()
@@ -53,12 +94,15 @@ func NewBeaconAPIClient(ctx context.Context, beaconAPIAddress string) (BeaconAPI
 	}
 
 	return BeaconAPIClient{
-		ctx:       ctx,
-		cancel:    cancel,
-		client:    client,
-		url:       beaconAPIAddress,
-		Retries:   60,
-		RetryWait: 10 * time.Second,
+		ctx:        ctx,
+		cancel:     cancel,
+		client:     client,
+		httpClient: httpClient,
+		url:        beaconAPIAddress,
+		Retries:    60,
+		RetryWait:  10 * time.Second,
+
+		LightClientUpdatesPerRequest: MaxRequestLightClientUpdates,
 	}, nil
 }
 
@@ -99,7 +143,7 @@ func (b BeaconAPIClient) GetBootstrap(finalizedRoot phase0.Root) (Bootstrap, err
 			return Bootstrap{}, err
 		}
 		req.Header.Set("Accept", "application/json")
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := b.httpClient.Do(req)
 		if err != nil {
 			return Bootstrap{}, err
 		}
@@ -123,6 +167,66 @@ func (b BeaconAPIClient) GetBootstrap(finalizedRoot phase0.Root) (Bootstrap, err
 	})
 }
 
+func (b BeaconAPIClient) GetLightClientUpdates(startPeriod uint64, count uint64) (LightClientUpdatesResponse, error) {
+	return retry(b.Retries, b.RetryWait, func() (LightClientUpdatesResponse, error) {
+		url := fmt.Sprintf("%s/eth/v1/beacon/light_client/updates?start_period=%d&count=%d", b.url, startPeriod, count)
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		resp, err := b.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != 200 {
+			return nil, fmt.Errorf("get light client updates (%s) failed with status code: %d, body: %s", url, resp.StatusCode, body)
+		}
+
+		var lightClientUpdates LightClientUpdatesResponse
+		if err := json.Unmarshal(body, &lightClientUpdates); err != nil {
+			return nil, err
+		}
+
+		return lightClientUpdates, nil
+	})
+}
+
+// GetLightClientUpdatesRange returns the light client updates of the periods from fromPeriod to toPeriod
+// inclusive, in order. Beacon nodes cap the updates served per request, so the range is fetched in pages
+// of at most LightClientUpdatesPerRequest periods.
+func (b BeaconAPIClient) GetLightClientUpdatesRange(fromPeriod, toPeriod uint64) (LightClientUpdatesResponse, error) {
+	if fromPeriod > toPeriod {
+		return nil, fmt.Errorf("%w: from period %d is after to period %d", ErrInvalidPeriodRange, fromPeriod, toPeriod)
+	}
+
+	perRequest := b.LightClientUpdatesPerRequest
+	if perRequest == 0 {
+		perRequest = MaxRequestLightClientUpdates
+	}
+
+	var lightClientUpdates LightClientUpdatesResponse
+	for start := fromPeriod; ; start += perRequest {
+		count := min(perRequest, toPeriod-start+1)
+		page, err := b.GetLightClientUpdates(start, count)
+		if err != nil {
+			return nil, fmt.Errorf("get light client updates from period %d: %w", start, err)
+		}
+		lightClientUpdates = append(lightClientUpdates, page...)
+
+		if toPeriod-start < perRequest {
+			return lightClientUpdates, nil
+		}
+	}
+}
+
 func (b BeaconAPIClient) GetSpec() (Spec, error) {
 	return retry(b.Retries, b.RetryWait, func() (Spec, error) {
 		specResponse, err := b.client.(eth2client.SpecProvider).Spec(b.ctx, &api.SpecOpts{})
@@ -153,7 +257,7 @@ func (b BeaconAPIClient) GetFinalityUpdate() (FinalityUpdateJSONResponse, error)
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := b.httpClient.Do(req)
 		if err != nil {
 			return FinalityUpdateJSONResponse{}, err
 		}
@@ -182,7 +286,7 @@ func (b BeaconAPIClient) GetBeaconBlocks(blockID string) (BeaconBlocksResponseJS
 		}
 
 		req.Header.Set("Accept", "application/json")
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := b.httpClient.Do(req)
 		if err != nil {
 			return BeaconBlocksResponseJSON{}, err
 		}
@@ -0,0 +1,138 @@
+package ethereum_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/srdtrk/solidity-ibc-eureka/e2e/v8/ethereum"
+	ethereumtypes "github.com/srdtrk/solidity-ibc-eureka/e2e/v8/types/ethereum"
+)
+
+func TestSpecPeriod(t *testing.T) {
+	testCases := []struct {
+		name        string
+		spec        ethereum.Spec
+		expected    uint64
+		expectedErr string
+	}{
+		{
+			name:     "mainnet preset",
+			spec:     ethereum.Spec{SlotsPerEpoch: 32, EpochsPerSyncCommitteePeriod: 256},
+			expected: 8192,
+		},
+		{
+			name:        "zeroed spec",
+			spec:        ethereum.Spec{},
+			expectedErr: "SLOTS_PER_EPOCH is zero",
+		},
+		{
+			name:        "zero epochs per period",
+			spec:        ethereum.Spec{SlotsPerEpoch: 32},
+			expectedErr: "EPOCHS_PER_SYNC_COMMITTEE_PERIOD is zero",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			period, err := tc.spec.Period()
+			if tc.expectedErr != "" {
+				require.ErrorIs(t, err, ethereum.ErrInvalidSpec)
+				require.ErrorContains(t, err, tc.expectedErr)
+
+				require.NotPanics(t, func() {
+					_, err = tc.spec.PeriodAtSlot(100)
+				})
+				require.ErrorIs(t, err, ethereum.ErrInvalidSpec)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, period)
+
+			currentPeriod, err := tc.spec.PeriodAtSlot(3*tc.expected + 1)
+			require.NoError(t, err)
+			require.Equal(t, uint64(3), currentPeriod)
+		})
+	}
+}
+
+func TestGetLightClientUpdatesRange(t *testing.T) {
+	const maxCount = 2
+
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/eth/v1/node/syncing":
+			_, _ = w.Write([]byte(`{"data":{"head_slot":"1","sync_distance":"0","is_syncing":false,"is_optimistic":false,"el_offline":false}}`))
+		case "/eth/v1/node/version":
+			_, _ = w.Write([]byte(`{"data":{"version":"stub"}}`))
+		case "/eth/v1/beacon/light_client/updates":
+			mu.Lock()
+			requests = append(requests, r.URL.RawQuery)
+			mu.Unlock()
+
+			startPeriod, err := strconv.ParseUint(r.URL.Query().Get("start_period"), 10, 64)
+			require.NoError(t, err)
+			count, err := strconv.ParseUint(r.URL.Query().Get("count"), 10, 64)
+			require.NoError(t, err)
+			if count > maxCount {
+				http.Error(w, "count exceeds MAX_REQUEST_LIGHT_CLIENT_UPDATES", http.StatusBadRequest)
+				return
+			}
+
+			// The signature slot identifies the period the update belongs to
+			var updates ethereum.LightClientUpdatesResponse
+			for period := startPeriod; period < startPeriod+count; period++ {
+				updates = append(updates, ethereum.LightClientUpdateJSON{
+					Data: ethereumtypes.LightClientUpdate{SignatureSlot: strconv.FormatUint(period, 10)},
+				})
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(updates))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ethereum.NewBeaconAPIClient(context.Background(), server.URL)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	client.Retries = 1
+	client.RetryWait = 0
+	client.LightClientUpdatesPerRequest = maxCount
+
+	updates, err := client.GetLightClientUpdatesRange(3, 7)
+	require.NoError(t, err)
+	var periods []uint64
+	for _, update := range updates {
+		periods = append(periods, update.Data.GetSignatureSlot())
+	}
+	require.Equal(t, []uint64{3, 4, 5, 6, 7}, periods)
+	mu.Lock()
+	require.Equal(t, []string{"start_period=3&count=2", "start_period=5&count=2", "start_period=7&count=1"}, requests)
+	mu.Unlock()
+
+	updates, err = client.GetLightClientUpdatesRange(4, 4)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+
+	_, err = client.GetLightClientUpdatesRange(5, 4)
+	require.ErrorIs(t, err, ethereum.ErrInvalidPeriodRange)
+
+	// Without the cap the stub server rejects the request
+	client.LightClientUpdatesPerRequest = 3
+	_, err = client.GetLightClientUpdatesRange(0, 5)
+	require.ErrorContains(t, err, "status code: 400")
+}
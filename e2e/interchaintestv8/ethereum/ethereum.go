@@ -5,17 +5,22 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
+	"net/http"
 	"os"
 	"os/exec"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"cosmossdk.io/math"
 
@@ -33,10 +38,17 @@ type Ethereum struct {
 }
 
 func NewEthereum(ctx context.Context, rpc string, beaconAPIClient *BeaconAPIClient, faucet *ecdsa.PrivateKey) (Ethereum, error) {
-	ethClient, err := ethclient.Dial(rpc)
+	return NewEthereumWithHTTPClient(ctx, rpc, new(http.Client), beaconAPIClient, faucet)
+}
+
+// NewEthereumWithHTTPClient is like NewEthereum but sends all JSON-RPC requests through httpClient,
+// which allows injecting timeouts, proxies, custom TLS or test transports.
+func NewEthereumWithHTTPClient(ctx context.Context, rpc string, httpClient *http.Client, beaconAPIClient *BeaconAPIClient, faucet *ecdsa.PrivateKey) (Ethereum, error) {
+	rpcClient, err := ethrpc.DialOptions(ctx, rpc, ethrpc.WithHTTPClient(httpClient))
 	if err != nil {
 		return Ethereum{}, err
 	}
+	ethClient := ethclient.NewClient(rpcClient)
 	chainID, err := ethClient.ChainID(ctx)
 	if err != nil {
 		return Ethereum{}, err
@@ -60,6 +72,62 @@ func (e *Ethereum) GetBlockTime(ctx context.Context) (int64, error) {
 	return int64(header.Time), nil
 }
 
+// WaitForBlock polls the latest block number every pollInterval until the chain reaches target, and
+// returns the observed height. It returns an error if ctx is done first.
+func (e *Ethereum) WaitForBlock(ctx context.Context, target uint64, pollInterval time.Duration) (uint64, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		height, err := e.RPCClient.BlockNumber(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get block number: %w", err)
+		}
+		if height >= target {
+			return height, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("waiting for block %d, last seen %d: %w", target, height, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Call performs an eth_call of data against the contract at to and returns the raw return data, which
+// allows querying any view without a binding. blockTag is a block number in hex or a tag such as
+// "latest", "safe" or "finalized", and defaults to "latest" when empty.
+func (e *Ethereum) Call(ctx context.Context, to ethcommon.Address, data []byte, blockTag string) ([]byte, error) {
+	if blockTag == "" {
+		blockTag = "latest"
+	}
+
+	callArgs := map[string]any{
+		"to":   to,
+		"data": hexutil.Bytes(data),
+	}
+
+	var result hexutil.Bytes
+	if err := e.RPCClient.Client().CallContext(ctx, &result, "eth_call", callArgs, blockTag); err != nil {
+		return nil, fmt.Errorf("eth_call to %s at %s: %w", to, blockTag, err)
+	}
+
+	return result, nil
+}
+
+// RawCall issues the JSON-RPC call method with params and returns its raw result, for debugging with
+// methods this package does not wrap, such as eth_getStorageAt. It is an unstable escape hatch: its
+// signature may change and callers should not depend on it outside of debugging and tests.
+func (e *Ethereum) RawCall(ctx context.Context, method string, params ...any) (json.RawMessage, error) {
+	var result json.RawMessage
+	if err := e.RPCClient.Client().CallContext(ctx, &result, method, params...); err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+
+	return result, nil
+}
+
 // BroadcastMessages broadcasts the provided messages to the given chain and signs them on behalf of the provided user.
 // Once the transaction is mined, the receipt is returned.
 func (e *Ethereum) BroadcastTx(ctx context.Context, userKey *ecdsa.PrivateKey, gasLimit uint64, address *ethcommon.Address, txBz []byte) (*ethtypes.Receipt, error) {
@@ -0,0 +1,222 @@
+package ethereum_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/srdtrk/solidity-ibc-eureka/e2e/v8/ethereum"
+)
+
+type rpcRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// newRPCServer starts a JSON-RPC server answering each request with handle's result or error
+func newRPCServer(t *testing.T, handle func(method string, params json.RawMessage) (any, *rpcError)) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		result, rpcErr := handle(req.Method, req.Params)
+		if rpcErr != nil {
+			resp["error"] = rpcErr
+		} else {
+			resp["result"] = result
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// countingTransport counts the requests sent through it
+type countingTransport struct {
+	requests atomic.Int64
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.requests.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewEthereumWithHTTPClient(t *testing.T) {
+	server := newRPCServer(t, func(method string, _ json.RawMessage) (any, *rpcError) {
+		assert.Equal(t, "eth_chainId", method)
+		return "0x539", nil
+	})
+
+	transport := &countingTransport{}
+	eth, err := ethereum.NewEthereumWithHTTPClient(context.Background(), server.URL, &http.Client{Transport: transport}, nil, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1337), eth.ChainID.Int64())
+	require.Equal(t, server.URL, eth.RPC)
+	require.Equal(t, int64(1), transport.requests.Load())
+}
+
+func TestNewEthereum(t *testing.T) {
+	server := newRPCServer(t, func(string, json.RawMessage) (any, *rpcError) {
+		return "0x1", nil
+	})
+
+	eth, err := ethereum.NewEthereum(context.Background(), server.URL, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), eth.ChainID.Int64())
+}
+
+func TestCall(t *testing.T) {
+	contract := ethcommon.HexToAddress("0x68B1D87F95878fE05B998F19b66F4baba5De1aed")
+	calldata := []byte{0x06, 0xfd, 0xde, 0x03} // name()
+	returnData := "0x" + strings.Repeat("00", 31) + "2a"
+
+	var (
+		mu        sync.Mutex
+		blockTags []string
+	)
+	server := newRPCServer(t, func(method string, params json.RawMessage) (any, *rpcError) {
+		switch method {
+		case "eth_chainId":
+			return "0x1", nil
+		case "eth_call":
+			var args []json.RawMessage
+			assert.NoError(t, json.Unmarshal(params, &args))
+			assert.Len(t, args, 2)
+
+			var callArgs struct {
+				To   ethcommon.Address `json:"to"`
+				Data hexutil.Bytes     `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(args[0], &callArgs))
+			assert.Equal(t, contract, callArgs.To)
+			assert.Equal(t, calldata, []byte(callArgs.Data))
+
+			var blockTag string
+			assert.NoError(t, json.Unmarshal(args[1], &blockTag))
+			mu.Lock()
+			blockTags = append(blockTags, blockTag)
+			mu.Unlock()
+			if blockTag == "0x0" {
+				return nil, &rpcError{Code: -32000, Message: "execution reverted"}
+			}
+			return returnData, nil
+		default:
+			return nil, &rpcError{Code: -32601, Message: "method not found"}
+		}
+	})
+
+	eth, err := ethereum.NewEthereum(context.Background(), server.URL, nil, nil)
+	require.NoError(t, err)
+
+	result, err := eth.Call(context.Background(), contract, calldata, "")
+	require.NoError(t, err)
+	require.Equal(t, hexutil.MustDecode(returnData), result)
+
+	result, err = eth.Call(context.Background(), contract, calldata, "finalized")
+	require.NoError(t, err)
+	require.Equal(t, hexutil.MustDecode(returnData), result)
+
+	_, err = eth.Call(context.Background(), contract, calldata, "0x0")
+	require.ErrorContains(t, err, "execution reverted")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"latest", "finalized", "0x0"}, blockTags)
+}
+
+func TestRawCall(t *testing.T) {
+	contract := ethcommon.HexToAddress("0x68B1D87F95878fE05B998F19b66F4baba5De1aed")
+	slot := "0x" + strings.Repeat("00", 31) + "01"
+	value := "0x" + strings.Repeat("00", 31) + "2a"
+
+	server := newRPCServer(t, func(method string, params json.RawMessage) (any, *rpcError) {
+		switch method {
+		case "eth_chainId":
+			return "0x1", nil
+		case "eth_getStorageAt":
+			var args []string
+			assert.NoError(t, json.Unmarshal(params, &args))
+			assert.Equal(t, []string{strings.ToLower(contract.Hex()), slot, "latest"}, args)
+			return value, nil
+		case "debug_traceConfig":
+			return map[string]any{"tracer": "callTracer", "enabled": true}, nil
+		default:
+			return nil, &rpcError{Code: -32601, Message: "method not found"}
+		}
+	})
+
+	eth, err := ethereum.NewEthereum(context.Background(), server.URL, nil, nil)
+	require.NoError(t, err)
+
+	result, err := eth.RawCall(context.Background(), "eth_getStorageAt", contract, slot, "latest")
+	require.NoError(t, err)
+	require.JSONEq(t, `"`+value+`"`, string(result))
+
+	// Results are returned as is, whatever their shape
+	result, err = eth.RawCall(context.Background(), "debug_traceConfig")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"tracer": "callTracer", "enabled": true}`, string(result))
+
+	_, err = eth.RawCall(context.Background(), "eth_unknown")
+	require.ErrorContains(t, err, "eth_unknown: method not found")
+}
+
+func TestWaitForBlock(t *testing.T) {
+	var height atomic.Uint64
+	server := newRPCServer(t, func(method string, _ json.RawMessage) (any, *rpcError) {
+		switch method {
+		case "eth_chainId":
+			return "0x1", nil
+		case "eth_blockNumber":
+			// The chain advances by one block on every poll
+			return hexutil.Uint64(height.Add(1)), nil
+		default:
+			return nil, &rpcError{Code: -32601, Message: "method not found"}
+		}
+	})
+
+	eth, err := ethereum.NewEthereum(context.Background(), server.URL, nil, nil)
+	require.NoError(t, err)
+
+	observed, err := eth.WaitForBlock(context.Background(), 3, time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), observed)
+
+	// Already reached targets return on the first poll
+	observed, err = eth.WaitForBlock(context.Background(), 1, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), observed)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = eth.WaitForBlock(ctx, 1_000_000, 5*time.Millisecond)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
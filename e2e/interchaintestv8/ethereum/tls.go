@@ -0,0 +1,55 @@
+package ethereum
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// ErrInvalidTLSConfig is returned when a TLSConfig cannot be turned into an HTTP client
+var ErrInvalidTLSConfig = errors.New("invalid TLS config")
+
+// TLSConfig holds the files needed to reach an eth or beacon node whose certificate is signed by a private CA
+type TLSConfig struct {
+	// CAFile is a PEM bundle of the CAs to trust. When empty, the system roots are used.
+	CAFile string
+	// CertFile and KeyFile are the PEM client certificate and key for mutual TLS, set both or neither
+	CertFile string
+	KeyFile  string
+}
+
+// NewTLSHTTPClient returns an HTTP client trusting the CAs of cfg and presenting its client certificate,
+// to be passed to NewEthereumWithHTTPClient and NewBeaconAPIClientWithHTTPClient.
+func NewTLSHTTPClient(cfg TLSConfig) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading CA file: %w", ErrInvalidTLSConfig, err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: no certificates found in CA file %s", ErrInvalidTLSConfig, cfg.CAFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, fmt.Errorf("%w: client certificate and key must be set together", ErrInvalidTLSConfig)
+	}
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: loading client certificate: %w", ErrInvalidTLSConfig, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}, nil
+}
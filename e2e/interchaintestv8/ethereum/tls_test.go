@@ -0,0 +1,115 @@
+package ethereum_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/srdtrk/solidity-ibc-eureka/e2e/v8/ethereum"
+)
+
+// newTLSNodeServer starts a TLS server acting as both the eth JSON-RPC and the beacon API of a node, recording
+// whether the last request presented a client certificate
+func newTLSNodeServer(t *testing.T, clientCert *atomic.Bool) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientCert.Store(len(r.TLS.PeerCertificates) > 0)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/":
+			var req rpcRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": "0x539"}))
+		case "/eth/v1/node/syncing":
+			_, _ = w.Write([]byte(`{"data":{"head_slot":"1","sync_distance":"0","is_syncing":false,"is_optimistic":false,"el_offline":false}}`))
+		case "/eth/v1/node/version":
+			_, _ = w.Write([]byte(`{"data":{"version":"stub"}}`))
+		case "/eth/v1/beacon/light_client/updates":
+			_, _ = w.Write([]byte(`[{"data":{"signature_slot":"8192"}}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), blockType+".pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func TestNewTLSHTTPClient(t *testing.T) {
+	var clientCert atomic.Bool
+	server := newTLSNodeServer(t, &clientCert)
+	caFile := writePEM(t, "CERTIFICATE", server.Certificate().Raw)
+
+	httpClient, err := ethereum.NewTLSHTTPClient(ethereum.TLSConfig{CAFile: caFile})
+	require.NoError(t, err)
+
+	eth, err := ethereum.NewEthereumWithHTTPClient(context.Background(), server.URL, httpClient, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1337), eth.ChainID.Int64())
+	require.False(t, clientCert.Load())
+
+	beacon, err := ethereum.NewBeaconAPIClientWithHTTPClient(context.Background(), server.URL, httpClient)
+	require.NoError(t, err)
+	t.Cleanup(beacon.Close)
+	beacon.Retries = 1
+	updates, err := beacon.GetLightClientUpdates(1, 1)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+
+	// The system roots do not trust the private CA
+	systemClient, err := ethereum.NewTLSHTTPClient(ethereum.TLSConfig{})
+	require.NoError(t, err)
+	_, err = ethereum.NewEthereumWithHTTPClient(context.Background(), server.URL, systemClient, nil, nil)
+	var unknownAuthority x509.UnknownAuthorityError
+	require.ErrorAs(t, err, &unknownAuthority)
+
+	t.Run("mutual TLS", func(t *testing.T) {
+		// The server's own key pair doubles as the client certificate
+		keyDER, err := x509.MarshalPKCS8PrivateKey(server.TLS.Certificates[0].PrivateKey)
+		require.NoError(t, err)
+		httpClient, err := ethereum.NewTLSHTTPClient(ethereum.TLSConfig{
+			CAFile:   caFile,
+			CertFile: caFile,
+			KeyFile:  writePEM(t, "PRIVATE KEY", keyDER),
+		})
+		require.NoError(t, err)
+
+		_, err = ethereum.NewEthereumWithHTTPClient(context.Background(), server.URL, httpClient, nil, nil)
+		require.NoError(t, err)
+		require.True(t, clientCert.Load())
+	})
+
+	for name, cfg := range map[string]ethereum.TLSConfig{
+		"missing CA file":       {CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+		"CA file without certs": {CAFile: writePEM(t, "PRIVATE KEY", []byte("not a certificate"))},
+		"certificate alone":     {CAFile: caFile, CertFile: caFile},
+		"mismatched key pair":   {CertFile: caFile, KeyFile: caFile},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ethereum.NewTLSHTTPClient(cfg)
+			require.ErrorIs(t, err, ethereum.ErrInvalidTLSConfig)
+		})
+	}
+}
@@ -2,24 +2,95 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"text/template"
+
+	solanago "github.com/gagliardetto/solana-go"
 )
 
 const (
 	seedKindConst   = "const"
 	seedKindArg     = "arg"
 	seedKindAccount = "account"
+
+	// generatedPackageName is the package of the generated files
+	generatedPackageName = "solana"
+	// solanaGoImportPath is the import path of solana-go, which the generated helpers reference as solanago
+	solanaGoImportPath = "github.com/gagliardetto/solana-go"
+)
+
+// defaultHeaderTemplate is the header of the generated files when no --header-template is given
+const defaultHeaderTemplate = `// Code generated by tools/generate-pdas. DO NOT EDIT.
+//
+// This file is automatically generated from Anchor IDL files.
+// Run 'just generate-pda' to regenerate.
+//
+// DO NOT EDIT THIS FILE MANUALLY.
+
+package {{.PackageName}}
+
+import (
+	"fmt"
+
+	solanago "{{.ImportPath}}"
 )
 
+`
+
+// ErrStaleOutput is returned in check mode when the output file differs from the generated code
+var ErrStaleOutput = errors.New("generated output is stale")
+
+// ErrInvalidOutputName is returned when --output does not name a Go file
+var ErrInvalidOutputName = errors.New("output must be a .go file")
+
+// ErrInvalidProgramID is returned when an IDL address is not a base58 encoded public key
+var ErrInvalidProgramID = errors.New("invalid program ID")
+
+// ErrInvalidSeeds is returned for a PDA whose seeds FindProgramAddress can never accept
+var ErrInvalidSeeds = errors.New("invalid PDA seeds")
+
+// ErrInvalidHeaderTemplate is returned when the header template cannot be rendered or does not yield valid Go
+var ErrInvalidHeaderTemplate = errors.New("invalid header template")
+
+// maxPDASeeds is the number of seeds a PDA may declare, FindProgramAddress appends the bump as the last of solanago.MaxSeeds
+const maxPDASeeds = solanago.MaxSeeds - 1
+
 // Configuration holds the command-line configuration
 type Configuration struct {
 	IDLDirectory string
 	OutputFile   string
+	// Check compares the generated code against OutputFile instead of writing it
+	Check bool
+	// SplitByProgram treats OutputFile as a directory and writes one <program>_pda.go file per program
+	SplitByProgram bool
+	// WithAddressVariants also generates an <Xxx>PDAAddress variant of every helper that discards the bump
+	WithAddressVariants bool
+	// TestOnly puts the generated files behind the `test` build tag so they do not ship in production binaries
+	TestOnly bool
+	// HeaderTemplate is the path of a text/template file rendered in place of the built-in header. It receives
+	// a HeaderData and must declare the package and import fmt and solana-go as solanago.
+	HeaderTemplate string
+	// Verbose, when set, receives a log of each IDL file processed, each pattern found and each pattern skipped
+	Verbose io.Writer
+}
+
+// HeaderData is passed to the header template
+type HeaderData struct {
+	// PackageName is the package of the generated files
+	PackageName string
+	// ImportPath is the import path of solana-go, which the generated helpers reference as solanago
+	ImportPath string
 }
 
 // IDL Types - Domain models for Anchor IDL structure
@@ -53,6 +124,37 @@ type Seed struct {
 	Path  string `json:"path,omitempty"`
 }
 
+// UnmarshalJSON accepts a const seed value encoded either as a byte array or, as older
+// Anchor IDLs do, as a string whose UTF-8 bytes are the seed
+func (s *Seed) UnmarshalJSON(data []byte) error {
+	type seedAlias Seed
+	var raw struct {
+		seedAlias
+		Value json.RawMessage `json:"value,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*s = Seed(raw.seedAlias)
+	if len(raw.Value) == 0 || string(raw.Value) == "null" {
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(raw.Value, &str); err == nil {
+		s.Value = []byte(str)
+		return nil
+	}
+
+	var bytes []byte
+	if err := json.Unmarshal(raw.Value, &bytes); err != nil {
+		return fmt.Errorf("seed value must be a byte array or a string: %w", err)
+	}
+	s.Value = bytes
+	return nil
+}
+
 // PDAPattern represents a unique PDA pattern to generate
 type PDAPattern struct {
 	Name        string
@@ -77,26 +179,152 @@ func NewGenerator(config *Configuration) *Generator {
 
 // Run executes the complete generation process
 func (g *Generator) Run() error {
+	if g.config.TestOnly && !g.config.SplitByProgram && filepath.Ext(g.config.OutputFile) != ".go" {
+		return fmt.Errorf("%w: %s", ErrInvalidOutputName, g.config.OutputFile)
+	}
+
 	// Extract PDA patterns from IDL files
 	if err := g.extractPatterns(); err != nil {
 		return fmt.Errorf("extracting patterns: %w", err)
 	}
 
-	// Generate Go code
-	code, err := g.generateCode()
+	// Generate Go code, keyed by output path
+	files, err := g.generateFiles()
 	if err != nil {
 		return fmt.Errorf("generating code: %w", err)
 	}
 
-	// Write output file
-	if err := os.WriteFile(g.config.OutputFile, []byte(code), 0o600); err != nil {
-		return fmt.Errorf("writing output: %w", err)
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
 	}
+	sort.Strings(paths)
 
-	fmt.Printf("Generated %d PDA helpers to %s\n", len(g.patterns), g.config.OutputFile)
+	if g.config.HeaderTemplate != "" {
+		for _, path := range paths {
+			if err := validateGenerated(path, files[path]); err != nil {
+				return fmt.Errorf("%w %s: %w", ErrInvalidHeaderTemplate, g.config.HeaderTemplate, err)
+			}
+		}
+	}
+
+	for _, path := range paths {
+		// Format the same way `just generate-pda` does so check mode compares like for like
+		formatted, err := format.Source([]byte(files[path]))
+		if err != nil {
+			return fmt.Errorf("formatting generated code for %s: %w", path, err)
+		}
+		files[path] = string(formatted)
+	}
+
+	if g.config.Check {
+		for _, path := range paths {
+			if err := checkOutput(path, files[path]); err != nil {
+				return err
+			}
+		}
+
+		fmt.Printf("%s is up to date (%d PDA helpers)\n", g.config.OutputFile, len(g.patterns))
+		return nil
+	}
+
+	if g.config.SplitByProgram {
+		if err := os.MkdirAll(g.config.OutputFile, 0o755); err != nil {
+			return fmt.Errorf("creating output directory: %w", err)
+		}
+	}
+
+	// Write output files
+	for _, path := range paths {
+		if err := os.WriteFile(path, []byte(files[path]), 0o600); err != nil {
+			return fmt.Errorf("writing output: %w", err)
+		}
+	}
+
+	if g.config.SplitByProgram {
+		fmt.Printf("Generated %d PDA helpers in %d files to %s\n", len(g.patterns), len(paths), g.config.OutputFile)
+	} else {
+		fmt.Printf("Generated %d PDA helpers to %s\n", len(g.patterns), g.config.OutputFile)
+	}
 	return nil
 }
 
+// validateGenerated checks that a generated file parses and imports what the helpers reference
+func validateGenerated(path, code string) error {
+	file, err := parser.ParseFile(token.NewFileSet(), path, code, parser.ImportsOnly)
+	if err != nil {
+		return err
+	}
+
+	if file.Name.Name != generatedPackageName {
+		return fmt.Errorf("package %s, expected %s", file.Name.Name, generatedPackageName)
+	}
+
+	var importsFmt, importsSolanaGo bool
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return err
+		}
+		switch {
+		case importPath == "fmt" && spec.Name == nil:
+			importsFmt = true
+		case importPath == solanaGoImportPath && spec.Name != nil && spec.Name.Name == "solanago":
+			importsSolanaGo = true
+		}
+	}
+	if !importsFmt {
+		return fmt.Errorf(`missing import "fmt"`)
+	}
+	if !importsSolanaGo {
+		return fmt.Errorf(`missing import solanago %q`, solanaGoImportPath)
+	}
+	return nil
+}
+
+// checkOutput compares the generated code against the existing file at path without writing it
+func checkOutput(path, code string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading output: %w", err)
+	}
+
+	if string(existing) != code {
+		return fmt.Errorf("%w: %s: %s", ErrStaleOutput, path, diffSummary(string(existing), code))
+	}
+
+	return nil
+}
+
+// diffSummary describes how many lines differ between current and generated and where they first diverge
+func diffSummary(current, generated string) string {
+	currentLines := strings.Split(current, "\n")
+	generatedLines := strings.Split(generated, "\n")
+
+	firstDiff := -1
+	differing := 0
+	for i := range max(len(currentLines), len(generatedLines)) {
+		var currentLine, generatedLine string
+		if i < len(currentLines) {
+			currentLine = currentLines[i]
+		}
+		if i < len(generatedLines) {
+			generatedLine = generatedLines[i]
+		}
+		if currentLine == generatedLine {
+			continue
+		}
+
+		differing++
+		if firstDiff == -1 {
+			firstDiff = i
+		}
+	}
+
+	return fmt.Sprintf("%d line(s) differ, first at line %d (committed %d lines, generated %d lines)",
+		differing, firstDiff+1, len(currentLines), len(generatedLines))
+}
+
 // extractPatterns reads all IDL files and extracts unique PDA patterns
 func (g *Generator) extractPatterns() error {
 	files, err := g.findIDLFiles()
@@ -104,37 +332,49 @@ func (g *Generator) extractPatterns() error {
 		return err
 	}
 
-	patterns := make([]PDAPattern, 0)
-	seenSignatures := make(map[string]bool)
-	seenFuncNames := make(map[string]bool)
-
+	var discovered []PDAPattern
 	for _, file := range files {
 		filePatterns, err := g.extractFromFile(file)
 		if err != nil {
 			return fmt.Errorf("processing %s: %w", file, err)
 		}
-
+		g.logf("%s: %d PDA account(s)", file, len(filePatterns))
 		for _, pattern := range filePatterns {
-			signature := pattern.buildSignature()
-			if seenSignatures[signature] {
-				continue
-			}
-			seenSignatures[signature] = true
-
-			pattern.FuncName = pattern.buildFuncName()
-			// Skip patterns that would produce duplicate Go method names.
-			// This handles IDLs with the same module name but different
-			// program addresses (e.g. access_manager vs test_access_manager)
-			// where program-address-derived const seeds differ but the
-			// generated method name is identical. The method takes programID
-			// as a runtime parameter so a single helper suffices.
-			if seenFuncNames[pattern.FuncName] {
-				continue
-			}
-			seenFuncNames[pattern.FuncName] = true
+			g.logf("  found %s.%s: %s", pattern.ProgramName, pattern.Name, pattern.buildSignature())
+		}
+		discovered = append(discovered, filePatterns...)
+	}
+
+	// Order candidates canonically before deduplicating so that the pattern kept
+	// for a signature does not depend on file or instruction order.
+	sortCanonical(discovered)
 
-			patterns = append(patterns, pattern)
+	patterns := make([]PDAPattern, 0)
+	seenSignatures := make(map[string]PDAPattern)
+	seenFuncNames := make(map[string]PDAPattern)
+
+	for _, pattern := range discovered {
+		signature := pattern.buildSignature()
+		if kept, seen := seenSignatures[signature]; seen {
+			g.logf("skipped %s.%s (%s): same signature as %s.%s (%s)", pattern.ProgramName, pattern.Name, pattern.ProgramID, kept.ProgramName, kept.Name, kept.ProgramID)
+			continue
+		}
+		seenSignatures[signature] = pattern
+
+		pattern.FuncName = pattern.buildFuncName()
+		// Skip patterns that would produce duplicate Go method names.
+		// This handles IDLs with the same module name but different
+		// program addresses (e.g. access_manager vs test_access_manager)
+		// where program-address-derived const seeds differ but the
+		// generated method name is identical. The method takes programID
+		// as a runtime parameter so a single helper suffices.
+		if kept, seen := seenFuncNames[pattern.FuncName]; seen {
+			g.logf("skipped %s.%s (%s): method %s already generated for %s.%s (%s)", pattern.ProgramName, pattern.Name, pattern.ProgramID, pattern.FuncName, kept.ProgramName, kept.Name, kept.ProgramID)
+			continue
 		}
+		seenFuncNames[pattern.FuncName] = pattern
+
+		patterns = append(patterns, pattern)
 	}
 
 	// Sort patterns by function name for consistent output
@@ -146,6 +386,39 @@ func (g *Generator) extractPatterns() error {
 	return nil
 }
 
+// logf writes a line to the verbose log, if enabled
+func (g *Generator) logf(format string, args ...any) {
+	if g.config.Verbose != nil {
+		fmt.Fprintf(g.config.Verbose, format+"\n", args...)
+	}
+}
+
+// sortCanonical orders patterns by program ID, then account name, then signature and seed paths
+func sortCanonical(patterns []PDAPattern) {
+	sort.SliceStable(patterns, func(i, j int) bool {
+		a, b := patterns[i], patterns[j]
+		if a.ProgramID != b.ProgramID {
+			return a.ProgramID < b.ProgramID
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if sigA, sigB := a.buildSignature(), b.buildSignature(); sigA != sigB {
+			return sigA < sigB
+		}
+		return a.seedPaths() < b.seedPaths()
+	})
+}
+
+// seedPaths joins the paths of all dynamic seeds, used as a final ordering tie-breaker
+func (p *PDAPattern) seedPaths() string {
+	var paths []string
+	for _, seed := range p.Seeds {
+		paths = append(paths, seed.Path)
+	}
+	return strings.Join(paths, "|")
+}
+
 // findIDLFiles discovers all JSON files in the IDL directory
 func (g *Generator) findIDLFiles() ([]string, error) {
 	entries, err := os.ReadDir(g.config.IDLDirectory)
@@ -180,12 +453,20 @@ func (g *Generator) extractFromFile(path string) ([]PDAPattern, error) {
 		return nil, fmt.Errorf("parsing JSON: %w", err)
 	}
 
+	// Validate here rather than leave a typo to fail at runtime in FindProgramAddress
+	if _, err := solanago.PublicKeyFromBase58(idl.Address); err != nil {
+		return nil, fmt.Errorf("%w %q in %s: %w", ErrInvalidProgramID, idl.Address, path, err)
+	}
+
 	programName := toPascalCase(idl.Metadata.Name)
 	var patterns []PDAPattern
 
 	for _, instruction := range idl.Instructions {
 		for _, account := range instruction.Accounts {
 			if account.PDA != nil {
+				if err := validateSeeds(account.PDA.Seeds); err != nil {
+					return nil, fmt.Errorf("%w for account %s of instruction %s in %s: %w", ErrInvalidSeeds, account.Name, instruction.Name, path, err)
+				}
 				patterns = append(patterns, PDAPattern{
 					Name:        account.Name,
 					Seeds:       account.PDA.Seeds,
@@ -199,6 +480,20 @@ func (g *Generator) extractFromFile(path string) ([]PDAPattern, error) {
 	return patterns, nil
 }
 
+// validateSeeds rejects seeds a generated helper could never derive an address from. Dynamic seeds are
+// only known at call time, so only their count is checked here.
+func validateSeeds(seeds []Seed) error {
+	if len(seeds) > maxPDASeeds {
+		return fmt.Errorf("%d seeds, at most %d are allowed", len(seeds), maxPDASeeds)
+	}
+	for i, seed := range seeds {
+		if seed.Kind == seedKindConst && len(seed.Value) > solanago.MaxSeedLength {
+			return fmt.Errorf("const seed %d (%s) is %d bytes, at most %d are allowed", i, describeSeed(seed), len(seed.Value), solanago.MaxSeedLength)
+		}
+	}
+	return nil
+}
+
 // buildSignature creates a unique signature for deduplication
 func (p *PDAPattern) buildSignature() string {
 	var parts []string
@@ -293,30 +588,75 @@ func (b *funcNameBuilder) hasArgSeed() bool {
 
 // CodeGenerator handles code generation
 type CodeGenerator struct {
-	patterns []PDAPattern
+	patterns            []PDAPattern
+	withAddressVariants bool
+	testOnly            bool
+	// header is the rendered header template, without the build constraint
+	header string
 }
 
-// generateCode creates the Go source code
-func (g *Generator) generateCode() (string, error) {
-	cg := &CodeGenerator{patterns: g.patterns}
-	return cg.generate()
+// generateFiles creates the Go source code, keyed by the path it is written to
+func (g *Generator) generateFiles() (map[string]string, error) {
+	header, err := g.renderHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	cg := &CodeGenerator{patterns: g.patterns, withAddressVariants: g.config.WithAddressVariants, testOnly: g.config.TestOnly, header: header}
+	if !g.config.SplitByProgram {
+		code, err := cg.generate()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{g.config.OutputFile: code}, nil
+	}
+
+	files := make(map[string]string)
+	programPatterns := cg.groupByProgram()
+	for _, programName := range sortedProgramNames(programPatterns) {
+		path := filepath.Join(g.config.OutputFile, toSnakeCase(programName)+"_pda.go")
+		files[path] = cg.generateFile([]string{programName}, programPatterns)
+	}
+	return files, nil
 }
 
-func (cg *CodeGenerator) generate() (string, error) {
-	var b strings.Builder
+// renderHeader renders the --header-template file, or the built-in header if none is given
+func (g *Generator) renderHeader() (string, error) {
+	text := defaultHeaderTemplate
+	if g.config.HeaderTemplate != "" {
+		data, err := os.ReadFile(g.config.HeaderTemplate)
+		if err != nil {
+			return "", fmt.Errorf("reading header template: %w", err)
+		}
+		text = string(data)
+	}
 
-	// Write file header
-	b.WriteString(cg.generateHeader())
+	tmpl, err := template.New("header").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w %s: %w", ErrInvalidHeaderTemplate, g.config.HeaderTemplate, err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, HeaderData{PackageName: generatedPackageName, ImportPath: solanaGoImportPath}); err != nil {
+		return "", fmt.Errorf("%w %s: %w", ErrInvalidHeaderTemplate, g.config.HeaderTemplate, err)
+	}
+	return b.String(), nil
+}
 
+func (cg *CodeGenerator) generate() (string, error) {
 	// Group patterns by program
 	programPatterns := cg.groupByProgram()
 
-	// Get sorted program names for consistent output
-	var programNames []string
-	for name := range programPatterns {
-		programNames = append(programNames, name)
-	}
-	sort.Strings(programNames)
+	return cg.generateFile(sortedProgramNames(programPatterns), programPatterns), nil
+}
+
+// generateFile creates a Go source file holding the PDA helpers of the given programs. All files share
+// the solana package, function names stay unique because each program has its own receiver type.
+func (cg *CodeGenerator) generateFile(programNames []string, programPatterns map[string][]PDAPattern) string {
+	var b strings.Builder
+
+	// Write file header
+	b.WriteString(cg.generateHeader())
 
 	// Generate type definitions and singleton instances
 	b.WriteString(cg.generateTypes(programNames))
@@ -328,10 +668,24 @@ func (cg *CodeGenerator) generate() (string, error) {
 		for _, pattern := range patterns {
 			b.WriteString(cg.generateMethod(programName, pattern))
 			b.WriteString("\n")
+			if cg.withAddressVariants {
+				b.WriteString(cg.generateAddressMethod(programName, pattern))
+				b.WriteString("\n")
+			}
 		}
 	}
 
-	return b.String(), nil
+	return b.String()
+}
+
+// sortedProgramNames returns the program names in sorted order for consistent output
+func sortedProgramNames(programPatterns map[string][]PDAPattern) []string {
+	var programNames []string
+	for name := range programPatterns {
+		programNames = append(programNames, name)
+	}
+	sort.Strings(programNames)
+	return programNames
 }
 
 func (cg *CodeGenerator) groupByProgram() map[string][]PDAPattern {
@@ -364,22 +718,12 @@ func (cg *CodeGenerator) generateTypes(programNames []string) string {
 }
 
 func (cg *CodeGenerator) generateHeader() string {
-	return `// Code generated by tools/generate-pdas. DO NOT EDIT.
-//
-// This file is automatically generated from Anchor IDL files.
-// Run 'just generate-pda' to regenerate.
-//
-// DO NOT EDIT THIS FILE MANUALLY.
-
-package solana
-
-import (
-	"fmt"
-
-	solanago "github.com/gagliardetto/solana-go"
-)
+	var buildConstraint string
+	if cg.testOnly {
+		buildConstraint = "//go:build test\n\n"
+	}
 
-`
+	return buildConstraint + cg.header
 }
 
 func (cg *CodeGenerator) generateMethod(programName string, p PDAPattern) string {
@@ -390,6 +734,15 @@ func (cg *CodeGenerator) generateMethod(programName string, p PDAPattern) string
 	return fg.generate()
 }
 
+// generateAddressMethod generates the variant of a PDA method that returns only the address
+func (cg *CodeGenerator) generateAddressMethod(programName string, p PDAPattern) string {
+	fg := &functionGenerator{
+		pattern:     p,
+		programName: programName,
+	}
+	return fg.generateAddress()
+}
+
 // functionGenerator generates a single PDA method
 type functionGenerator struct {
 	pattern     PDAPattern
@@ -399,6 +752,9 @@ type functionGenerator struct {
 func (fg *functionGenerator) generate() string {
 	var b strings.Builder
 
+	// Doc comment
+	b.WriteString(fg.generateDocComment())
+
 	// Function signature
 	b.WriteString(fg.generateSignature())
 	b.WriteString(" {\n")
@@ -419,34 +775,90 @@ func (fg *functionGenerator) generate() string {
 	return b.String()
 }
 
+// generateAddress generates a method delegating to the bump-returning one, so both always derive the same address
+func (fg *functionGenerator) generateAddress() string {
+	var b strings.Builder
+	methodName := strings.TrimPrefix(fg.pattern.FuncName, fg.programName)
+
+	fmt.Fprintf(&b, "// %sAddress is like %s but returns only the PDA address.\n", methodName, methodName)
+	fmt.Fprintf(&b, "func (%s) %sAddress(%s) solanago.PublicKey {\n", fg.receiverType(), methodName, fg.extractParameters())
+	// Call through the PascalCase singleton, which cannot collide with a camelCase seed parameter
+	fmt.Fprintf(&b, "\tpda, _ := %s.%s(%s)\n", fg.programName, methodName, strings.Join(fg.parameterNames(), ", "))
+	b.WriteString("\treturn pda\n")
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func (fg *functionGenerator) generateDocComment() string {
+	var b strings.Builder
+	methodName := strings.TrimPrefix(fg.pattern.FuncName, fg.programName)
+
+	fmt.Fprintf(&b, "// %s derives the %s PDA for the %s account.\n", methodName, fg.programName, fg.pattern.Name)
+	b.WriteString("//\n")
+	b.WriteString("// Seeds, in order:\n")
+	for _, seed := range fg.pattern.Seeds {
+		fmt.Fprintf(&b, "//   - %s\n", describeSeed(seed))
+	}
+
+	return b.String()
+}
+
+// describeSeed renders a single seed for a generated doc comment
+func describeSeed(seed Seed) string {
+	switch seed.Kind {
+	case seedKindConst:
+		if isPrintableASCII(seed.Value) {
+			return fmt.Sprintf("const %q", string(seed.Value))
+		}
+		return fmt.Sprintf("const 0x%x", seed.Value)
+	case seedKindArg, seedKindAccount:
+		return fmt.Sprintf("%s %s (%s)", seed.Kind, extractParamName(seed), seed.Path)
+	default:
+		return seed.Kind
+	}
+}
+
 func (fg *functionGenerator) generateSignature() string {
 	params := fg.extractParameters()
-	receiverType := strings.ToLower(fg.programName[:1]) + fg.programName[1:] + "PDAs"
 
 	// Strip program name prefix from method name
 	methodName := strings.TrimPrefix(fg.pattern.FuncName, fg.programName)
 
 	return fmt.Sprintf("func (%s) %s(%s) (solanago.PublicKey, uint8)",
-		receiverType, methodName, params)
+		fg.receiverType(), methodName, params)
+}
+
+func (fg *functionGenerator) receiverType() string {
+	return strings.ToLower(fg.programName[:1]) + fg.programName[1:] + "PDAs"
 }
 
 func (fg *functionGenerator) extractParameters() string {
 	params := []string{"programID solanago.PublicKey"}
+	for _, name := range fg.parameterNames()[1:] {
+		params = append(params, fmt.Sprintf("%s []byte", name))
+	}
+
+	return strings.Join(params, ", ")
+}
+
+// parameterNames returns the names of the method parameters in order, starting with programID
+func (fg *functionGenerator) parameterNames() []string {
+	names := []string{"programID"}
 	seen := make(map[string]bool)
 
 	for _, seed := range fg.pattern.Seeds {
 		if seed.Kind == seedKindArg || seed.Kind == seedKindAccount {
-			paramName := extractParamName(seed.Path)
-			paramKey := fmt.Sprintf("%s_%s", seed.Kind, paramName)
-
-			if !seen[paramKey] {
-				params = append(params, fmt.Sprintf("%s []byte", paramName))
-				seen[paramKey] = true
+			// Dedup by name alone, Go rejects a parameter declared twice whatever the seed kind
+			paramName := extractParamName(seed)
+			if !seen[paramName] {
+				names = append(names, paramName)
+				seen[paramName] = true
 			}
 		}
 	}
 
-	return strings.Join(params, ", ")
+	return names
 }
 
 func (fg *functionGenerator) generateSeedsArray() string {
@@ -463,7 +875,7 @@ func (fg *functionGenerator) buildSeedsCode() []string {
 		case seedKindConst:
 			seeds = append(seeds, formatBytesLiteral(seed.Value))
 		case seedKindArg, seedKindAccount:
-			seeds = append(seeds, extractParamName(seed.Path))
+			seeds = append(seeds, extractParamName(seed))
 		}
 	}
 
@@ -519,6 +931,21 @@ func toPascalCase(s string) string {
 	return strings.Join(parts, "")
 }
 
+// toSnakeCase converts a PascalCase program name to snake_case, e.g. Ics26Router to ics26_router
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 func toCamelCase(s string) string {
 	parts := strings.Split(s, "_")
 
@@ -531,9 +958,14 @@ func toCamelCase(s string) string {
 	return strings.Join(parts, "")
 }
 
-func extractParamName(path string) string {
-	parts := strings.Split(path, ".")
+// extractParamName returns the Go parameter name of a dynamic seed. Seeds referencing an
+// instruction arg by index rather than by name get a stable name like arg0.
+func extractParamName(seed Seed) string {
+	parts := strings.Split(seed.Path, ".")
 	name := parts[len(parts)-1]
+	if _, err := strconv.Atoi(name); err == nil {
+		return seed.Kind + name
+	}
 	return toCamelCase(name)
 }
 
@@ -542,9 +974,19 @@ func parseConfiguration() (*Configuration, error) {
 	var config Configuration
 
 	flag.StringVar(&config.IDLDirectory, "idl-dir", "", "Directory containing IDL JSON files")
-	flag.StringVar(&config.OutputFile, "output", "", "Output Go file")
+	flag.StringVar(&config.OutputFile, "output", "", "Output Go file, or output directory with --split-by-program")
+	flag.BoolVar(&config.Check, "check", false, "Verify the output file is up to date instead of writing it")
+	flag.BoolVar(&config.SplitByProgram, "split-by-program", false, "Write one <program>_pda.go file per program into the --output directory")
+	flag.BoolVar(&config.WithAddressVariants, "with-address-variants", false, "Also generate an <Xxx>PDAAddress variant of every helper returning only the address")
+	flag.BoolVar(&config.TestOnly, "test-only", false, "Add a \"test\" build tag so the helpers are only compiled with -tags test")
+	flag.StringVar(&config.HeaderTemplate, "header-template", "", "text/template file rendered in place of the built-in header, receiving .PackageName and .ImportPath")
+	verbose := flag.Bool("verbose", false, "Log each IDL file processed, each pattern found and each pattern skipped to stderr")
 	flag.Parse()
 
+	if *verbose {
+		config.Verbose = os.Stderr
+	}
+
 	if config.IDLDirectory == "" {
 		return nil, fmt.Errorf("--idl-dir is required")
 	}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateDocComment(t *testing.T) {
+	pattern := PDAPattern{
+		Name: "client_sequence",
+		Seeds: []Seed{
+			{Kind: seedKindConst, Value: []byte("client_sequence")},
+			{Kind: seedKindConst, Value: []byte{0xde, 0xad}},
+			{Kind: seedKindArg, Path: "msg.client_id"},
+			{Kind: seedKindAccount, Path: "payer"},
+		},
+		ProgramName: "Ics26Router",
+	}
+	pattern.FuncName = pattern.buildFuncName()
+
+	code := (&CodeGenerator{}).generateMethod(pattern.ProgramName, pattern)
+
+	doc, _, found := strings.Cut(code, "func ")
+	require.True(t, found)
+	require.Contains(t, doc, "// ClientSequenceWithArgAndAccountSeedPDA derives the Ics26Router PDA for the client_sequence account.")
+	require.Contains(t, doc, `//   - const "client_sequence"`)
+	require.Contains(t, doc, "//   - const 0xdead")
+	require.Contains(t, doc, "//   - arg clientId (msg.client_id)")
+	require.Contains(t, doc, "//   - account payer (payer)")
+
+	require.Less(t, strings.Index(doc, "client_sequence\""), strings.Index(doc, "0xdead"))
+	require.Less(t, strings.Index(doc, "0xdead"), strings.Index(doc, "clientId"))
+	require.Less(t, strings.Index(doc, "clientId"), strings.Index(doc, "payer"))
+}
+
+func TestDedupIndependentOfFileOrder(t *testing.T) {
+	fixtures := []string{"access_manager.json", "test_access_manager.json"}
+
+	generate := func(t *testing.T, order []string) string {
+		t.Helper()
+
+		// Copy the fixtures under names that force the given directory order
+		idlDir := t.TempDir()
+		for i, fixture := range order {
+			data, err := os.ReadFile(filepath.Join("testdata", "dedup", fixture))
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(idlDir, fmt.Sprintf("%d.json", i)), data, 0o600))
+		}
+
+		output := filepath.Join(t.TempDir(), "pda.go")
+		require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output}).Run())
+
+		code, err := os.ReadFile(output)
+		require.NoError(t, err)
+		return string(code)
+	}
+
+	forward := generate(t, fixtures)
+	reversed := generate(t, []string{fixtures[1], fixtures[0]})
+	require.Equal(t, forward, reversed)
+
+	// The lowest program ID wins, and within it the lowest account name
+	require.Contains(t, forward, "RoleWithArgSeedPDA(programID solanago.PublicKey, revokedRole []byte)")
+	require.Contains(t, forward, "for the role account.")
+}
+
+func TestCheckMode(t *testing.T) {
+	idlDir := filepath.Join("testdata", "dedup")
+	output := filepath.Join(t.TempDir(), "pda.go")
+
+	// Missing output file
+	err := NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output, Check: true}).Run()
+	require.ErrorContains(t, err, "reading output")
+
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output}).Run())
+	generated, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	// Up to date
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output, Check: true}).Run())
+
+	// Stale, and check mode must not rewrite it
+	stale := strings.Replace(string(generated), "programID solanago.PublicKey", "programId solanago.PublicKey", 1)
+	require.NoError(t, os.WriteFile(output, []byte(stale), 0o600))
+
+	err = NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output, Check: true}).Run()
+	require.ErrorIs(t, err, ErrStaleOutput)
+	require.ErrorContains(t, err, "1 line(s) differ")
+
+	current, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.Equal(t, stale, string(current))
+}
+
+func TestSplitByProgram(t *testing.T) {
+	idlDir := filepath.Join("testdata", "split")
+	outputDir := filepath.Join(t.TempDir(), "solana")
+
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: outputDir, SplitByProgram: true}).Run())
+
+	entries, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.Equal(t, []string{"access_manager_pda.go", "ics26_router_pda.go"}, names)
+
+	accessManager, err := os.ReadFile(filepath.Join(outputDir, "access_manager_pda.go"))
+	require.NoError(t, err)
+	router, err := os.ReadFile(filepath.Join(outputDir, "ics26_router_pda.go"))
+	require.NoError(t, err)
+
+	// Each file has the header and only its own program
+	for _, code := range []string{string(accessManager), string(router)} {
+		require.True(t, strings.HasPrefix(code, "// Code generated by tools/generate-pdas. DO NOT EDIT."))
+		require.Contains(t, code, "package solana")
+	}
+	require.Contains(t, string(accessManager), "func (accessManagerPDAs) RoleWithArgSeedPDA(")
+	require.NotContains(t, string(accessManager), "ics26RouterPDAs")
+	require.Contains(t, string(router), "func (ics26RouterPDAs) ClientWithArgSeedPDA(")
+	require.Contains(t, string(router), "func (ics26RouterPDAs) RoleWithArgSeedPDA(")
+	require.NotContains(t, string(router), "accessManagerPDAs")
+
+	// The split files compile together as one package, so no declaration may collide
+	fset := token.NewFileSet()
+	declared := make(map[string]string)
+	for _, name := range names {
+		file, err := parser.ParseFile(fset, filepath.Join(outputDir, name), nil, 0)
+		require.NoError(t, err)
+		for _, decl := range file.Decls {
+			for _, ident := range declaredNames(decl) {
+				previous, exists := declared[ident]
+				require.False(t, exists, "%s declared in both %s and %s", ident, previous, name)
+				declared[ident] = name
+			}
+		}
+	}
+
+	// Check mode verifies every file in the directory
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: outputDir, SplitByProgram: true, Check: true}).Run())
+	require.NoError(t, os.Remove(filepath.Join(outputDir, "ics26_router_pda.go")))
+	err = NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: outputDir, SplitByProgram: true, Check: true}).Run()
+	require.ErrorContains(t, err, "ics26_router_pda.go")
+}
+
+func TestLegacySeedEncoding(t *testing.T) {
+	generate := func(t *testing.T, encoding string) string {
+		t.Helper()
+
+		output := filepath.Join(t.TempDir(), "pda.go")
+		idlDir := filepath.Join("testdata", "seed_encoding", encoding)
+		require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output}).Run())
+
+		code, err := os.ReadFile(output)
+		require.NoError(t, err)
+		return string(code)
+	}
+
+	array := generate(t, "array")
+	legacy := generate(t, "string")
+	require.Equal(t, array, legacy)
+	require.Contains(t, legacy, "func (ics26RouterPDAs) ClientWithArgSeedPDA(")
+	require.Contains(t, legacy, `//   - const "client"`)
+
+	var seed Seed
+	require.NoError(t, json.Unmarshal([]byte(`{"kind": "const", "value": "role"}`), &seed))
+	require.Equal(t, Seed{Kind: seedKindConst, Value: []byte("role")}, seed)
+	require.NoError(t, json.Unmarshal([]byte(`{"kind": "const", "value": [114, 111, 108, 101]}`), &seed))
+	require.Equal(t, Seed{Kind: seedKindConst, Value: []byte("role")}, seed)
+	require.NoError(t, json.Unmarshal([]byte(`{"kind": "arg", "path": "client_id"}`), &seed))
+	require.Equal(t, Seed{Kind: seedKindArg, Path: "client_id"}, seed)
+	require.Error(t, json.Unmarshal([]byte(`{"kind": "const", "value": 1}`), &seed))
+}
+
+func TestPositionalArgSeeds(t *testing.T) {
+	idlDir := filepath.Join("testdata", "positional")
+	output := filepath.Join(t.TempDir(), "pda.go")
+
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output}).Run())
+
+	code, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.Contains(t, string(code), "CommitmentWithArgSeedPDA(programID solanago.PublicKey, arg0 []byte, arg1 []byte)")
+	require.Contains(t, string(code), "[][]byte{[]byte(\"commitment\"), arg0, arg1, arg0}")
+	require.Contains(t, string(code), "//   - arg arg1 (1)")
+	require.Contains(t, string(code), "ClientSequenceWithArgSeedPDA(programID solanago.PublicKey, arg0 []byte)")
+
+	_, err = parser.ParseFile(token.NewFileSet(), output, nil, 0)
+	require.NoError(t, err)
+}
+
+func TestWithAddressVariants(t *testing.T) {
+	idlDir := filepath.Join("testdata", "split")
+	output := filepath.Join(t.TempDir(), "pda.go")
+
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output}).Run())
+	code, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.NotContains(t, string(code), "PDAAddress(")
+
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output, WithAddressVariants: true}).Run())
+	code, err = os.ReadFile(output)
+	require.NoError(t, err)
+
+	// The bump-returning API is kept
+	require.Contains(t, string(code), "func (ics26RouterPDAs) ClientWithArgSeedPDA(programID solanago.PublicKey, clientId []byte) (solanago.PublicKey, uint8)")
+	require.Contains(t, string(code), "func (ics26RouterPDAs) ClientWithArgSeedPDAAddress(programID solanago.PublicKey, clientId []byte) solanago.PublicKey")
+
+	file, err := parser.ParseFile(token.NewFileSet(), output, nil, 0)
+	require.NoError(t, err)
+
+	methods := make(map[string]*ast.FuncDecl)
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil {
+			methods[fmt.Sprintf("%s.%s", fn.Recv.List[0].Type, fn.Name.Name)] = fn
+		}
+	}
+
+	// Every helper has an address variant that derives its address by delegating with the same arguments
+	var variants int
+	for name, fn := range methods {
+		base, isAddress := strings.CutSuffix(name, "Address")
+		if !isAddress {
+			require.Contains(t, methods, name+"Address")
+			continue
+		}
+		variants++
+		require.Contains(t, methods, base)
+
+		var params []string
+		for _, field := range fn.Type.Params.List {
+			params = append(params, field.Names[0].Name)
+		}
+
+		require.Len(t, fn.Body.List, 2)
+		assign := fn.Body.List[0].(*ast.AssignStmt)
+		require.Equal(t, "_", assign.Lhs[1].(*ast.Ident).Name)
+		call := assign.Rhs[0].(*ast.CallExpr)
+		selector := call.Fun.(*ast.SelectorExpr)
+		require.Equal(t, strings.TrimSuffix(fn.Name.Name, "Address"), selector.Sel.Name)
+
+		var args []string
+		for _, arg := range call.Args {
+			args = append(args, arg.(*ast.Ident).Name)
+		}
+		require.Equal(t, params, args)
+	}
+	require.Equal(t, len(methods)/2, variants)
+}
+
+// declaredNames returns the package-level names a declaration introduces, qualifying methods by receiver
+func declaredNames(decl ast.Decl) []string {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv != nil {
+			return []string{fmt.Sprintf("%s.%s", d.Recv.List[0].Type, d.Name.Name)}
+		}
+		return []string{d.Name.Name}
+	case *ast.GenDecl:
+		var names []string
+		for _, spec := range d.Specs {
+			switch sp := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, sp.Name.Name)
+			case *ast.ValueSpec:
+				for _, name := range sp.Names {
+					names = append(names, name.Name)
+				}
+			}
+		}
+		return names
+	default:
+		return nil
+	}
+}
+
+func TestInvalidProgramID(t *testing.T) {
+	idlDir := filepath.Join("testdata", "invalid_address")
+	output := filepath.Join(t.TempDir(), "pda.go")
+
+	err := NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output}).Run()
+	require.ErrorIs(t, err, ErrInvalidProgramID)
+	require.ErrorContains(t, err, "ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knO")
+	require.ErrorContains(t, err, filepath.Join(idlDir, "ics26_router.json"))
+	require.NoFileExists(t, output)
+}
+
+func TestTestOnly(t *testing.T) {
+	idlDir := filepath.Join("testdata", "split")
+	output := filepath.Join(t.TempDir(), "pda.go")
+
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output, TestOnly: true}).Run())
+	code, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	file, err := parser.ParseFile(token.NewFileSet(), output, code, parser.ParseComments)
+	require.NoError(t, err)
+	require.Equal(t, "//go:build test", file.Comments[0].List[0].Text)
+	require.Less(t, file.Comments[0].End(), file.Package, "the build constraint must precede the package clause")
+	require.Contains(t, string(code), "// Code generated by tools/generate-pdas. DO NOT EDIT.")
+
+	// Split files all carry the constraint
+	outputDir := filepath.Join(t.TempDir(), "solana")
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: outputDir, SplitByProgram: true, TestOnly: true}).Run())
+	for _, name := range []string{"access_manager_pda.go", "ics26_router_pda.go"} {
+		code, err := os.ReadFile(filepath.Join(outputDir, name))
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(code), "//go:build test\n\n"), name)
+	}
+
+	// Without the flag there is no constraint
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output}).Run())
+	code, err = os.ReadFile(output)
+	require.NoError(t, err)
+	require.NotContains(t, string(code), "//go:build")
+
+	err = NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: filepath.Join(t.TempDir(), "pda"), TestOnly: true}).Run()
+	require.ErrorIs(t, err, ErrInvalidOutputName)
+}
+
+func TestVerbose(t *testing.T) {
+	idlDir := filepath.Join("testdata", "dedup")
+	output := filepath.Join(t.TempDir(), "pda.go")
+
+	var log strings.Builder
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output, Verbose: &log}).Run())
+
+	lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+	require.Contains(t, lines, filepath.Join(idlDir, "access_manager.json")+": 1 PDA account(s)")
+	require.Contains(t, lines, filepath.Join(idlDir, "test_access_manager.json")+": 2 PDA account(s)")
+	require.Contains(t, lines, "  found AccessManager.role: AccessManager|role|arg")
+	require.Contains(t, lines, "skipped AccessManager.role_state (TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA): same signature as AccessManager.role (ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL)")
+
+	// The generated code does not depend on verbosity
+	verboseCode, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output}).Run())
+	code, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.Equal(t, string(code), string(verboseCode))
+}
+
+func TestInvalidSeeds(t *testing.T) {
+	idlDir := filepath.Join("testdata", "invalid_seed")
+	output := filepath.Join(t.TempDir(), "pda.go")
+
+	// The 32 byte const seed of client is accepted, the 34 byte one of client_sequence is not
+	err := NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output}).Run()
+	require.ErrorIs(t, err, ErrInvalidSeeds)
+	require.ErrorContains(t, err, "account client_sequence of instruction add_client")
+	require.ErrorContains(t, err, `const seed 0 (const "client_sequence_with_a_longer_name") is 34 bytes, at most 32 are allowed`)
+	require.ErrorContains(t, err, filepath.Join(idlDir, "ics26_router.json"))
+	require.NoFileExists(t, output)
+
+	// FindProgramAddress appends the bump, leaving room for 15 seeds of any length
+	seeds := make([]Seed, 15)
+	for i := range seeds {
+		seeds[i] = Seed{Kind: seedKindArg, Path: "arg"}
+	}
+	require.NoError(t, validateSeeds(seeds))
+	require.ErrorContains(t, validateSeeds(append(seeds, Seed{Kind: seedKindArg, Path: "arg"})), "16 seeds, at most 15 are allowed")
+}
+
+func TestHeaderTemplate(t *testing.T) {
+	idlDir := filepath.Join("testdata", "split")
+	output := filepath.Join(t.TempDir(), "pda.go")
+
+	headerTemplate := filepath.Join("testdata", "header_template", "header.go.tmpl")
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output, HeaderTemplate: headerTemplate, TestOnly: true}).Run())
+	code, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(code), "//go:build test\n\n// Copyright 2025 The IBC Eureka Authors\n"))
+	require.Contains(t, string(code), "//nolint:all\npackage solana\n")
+	require.Contains(t, string(code), `solanago "github.com/gagliardetto/solana-go"`)
+	require.NotContains(t, string(code), "Run 'just generate-pda' to regenerate.")
+
+	// The body is the same as with the built-in header
+	defaultOutput := filepath.Join(t.TempDir(), "pda.go")
+	require.NoError(t, NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: defaultOutput, TestOnly: true}).Run())
+	defaultCode, err := os.ReadFile(defaultOutput)
+	require.NoError(t, err)
+	body := func(code []byte) string {
+		_, body, found := strings.Cut(string(code), "\n)\n")
+		require.True(t, found)
+		return body
+	}
+	require.Equal(t, body(defaultCode), body(code))
+
+	testCases := []struct {
+		name     string
+		header   string
+		contains string
+	}{
+		{"unparsable template", "package {{.PackageName}\n", "bad character"},
+		{"unknown field", "package {{.Package}}\n", "can't evaluate field Package"},
+		{"wrong package", "package pda\n\nimport (\n\t\"fmt\"\n\n\tsolanago \"{{.ImportPath}}\"\n)\n", "package pda, expected solana"},
+		{"missing import", "package {{.PackageName}}\n\nimport \"fmt\"\n", `missing import solanago "github.com/gagliardetto/solana-go"`},
+		{"invalid go", "package {{.PackageName}}\n\nimport (\n", "missing import path"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			headerTemplate := filepath.Join(t.TempDir(), "header.go.tmpl")
+			require.NoError(t, os.WriteFile(headerTemplate, []byte(tc.header), 0o600))
+			output := filepath.Join(t.TempDir(), "pda.go")
+
+			err := NewGenerator(&Configuration{IDLDirectory: idlDir, OutputFile: output, HeaderTemplate: headerTemplate}).Run()
+			require.ErrorIs(t, err, ErrInvalidHeaderTemplate)
+			require.ErrorContains(t, err, tc.contains)
+			require.NoFileExists(t, output)
+		})
+	}
+}
@@ -37,6 +37,10 @@ var (
 	TestIbcApp      = testIbcAppPDAs{}
 )
 
+// AccessManagerPDA derives the AccessManager PDA for the access_manager account.
+//
+// Seeds, in order:
+//   - const "access_manager"
 func (accessManagerPDAs) AccessManagerPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("access_manager")},
@@ -48,6 +52,10 @@ func (accessManagerPDAs) AccessManagerPDA(programID solanago.PublicKey) (solanag
 	return pda, bump
 }
 
+// ProgramDataPDA derives the AccessManager PDA for the program_data account.
+//
+// Seeds, in order:
+//   - const 0x36668eef409623b83a37af3ef6c843feebb7be8d42bd513062543b2492d108aa
 func (accessManagerPDAs) ProgramDataPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte{0x36, 0x66, 0x8e, 0xef, 0x40, 0x96, 0x23, 0xb8, 0x3a, 0x37, 0xaf, 0x3e, 0xf6, 0xc8, 0x43, 0xfe, 0xeb, 0xb7, 0xbe, 0x8d, 0x42, 0xbd, 0x51, 0x30, 0x62, 0x54, 0x3b, 0x24, 0x92, 0xd1, 0x08, 0xaa}},
@@ -59,6 +67,10 @@ func (accessManagerPDAs) ProgramDataPDA(programID solanago.PublicKey) (solanago.
 	return pda, bump
 }
 
+// ProgramDataWithAccountSeedPDA derives the AccessManager PDA for the program_data account.
+//
+// Seeds, in order:
+//   - account program (program)
 func (accessManagerPDAs) ProgramDataWithAccountSeedPDA(programID solanago.PublicKey, program []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{program},
@@ -70,6 +82,10 @@ func (accessManagerPDAs) ProgramDataWithAccountSeedPDA(programID solanago.Public
 	return pda, bump
 }
 
+// ProgramDataWithArgSeedPDA derives the AccessManager PDA for the program_data account.
+//
+// Seeds, in order:
+//   - arg targetProgram (target_program)
 func (accessManagerPDAs) ProgramDataWithArgSeedPDA(programID solanago.PublicKey, targetProgram []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{targetProgram},
@@ -81,6 +97,11 @@ func (accessManagerPDAs) ProgramDataWithArgSeedPDA(programID solanago.PublicKey,
 	return pda, bump
 }
 
+// UpgradeAuthorityWithArgSeedPDA derives the AccessManager PDA for the new_upgrade_authority account.
+//
+// Seeds, in order:
+//   - const "upgrade_authority"
+//   - arg targetProgram (target_program)
 func (accessManagerPDAs) UpgradeAuthorityWithArgSeedPDA(programID solanago.PublicKey, targetProgram []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("upgrade_authority"), targetProgram},
@@ -92,6 +113,10 @@ func (accessManagerPDAs) UpgradeAuthorityWithArgSeedPDA(programID solanago.Publi
 	return pda, bump
 }
 
+// AccessManagerPDA derives the Attestation PDA for the access_manager account.
+//
+// Seeds, in order:
+//   - const "access_manager"
 func (attestationPDAs) AccessManagerPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("access_manager")},
@@ -103,6 +128,10 @@ func (attestationPDAs) AccessManagerPDA(programID solanago.PublicKey) (solanago.
 	return pda, bump
 }
 
+// AppStatePDA derives the Attestation PDA for the app_state account.
+//
+// Seeds, in order:
+//   - const "app_state"
 func (attestationPDAs) AppStatePDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("app_state")},
@@ -114,6 +143,10 @@ func (attestationPDAs) AppStatePDA(programID solanago.PublicKey) (solanago.Publi
 	return pda, bump
 }
 
+// ClientPDA derives the Attestation PDA for the client_state account.
+//
+// Seeds, in order:
+//   - const "client"
 func (attestationPDAs) ClientPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("client")},
@@ -125,6 +158,11 @@ func (attestationPDAs) ClientPDA(programID solanago.PublicKey) (solanago.PublicK
 	return pda, bump
 }
 
+// ConsensusStateWithAccountSeedPDA derives the Attestation PDA for the consensus_state account.
+//
+// Seeds, in order:
+//   - const "consensus_state"
+//   - account latestHeight (client_state.latest_height)
 func (attestationPDAs) ConsensusStateWithAccountSeedPDA(programID solanago.PublicKey, latestHeight []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("consensus_state"), latestHeight},
@@ -136,6 +174,11 @@ func (attestationPDAs) ConsensusStateWithAccountSeedPDA(programID solanago.Publi
 	return pda, bump
 }
 
+// ConsensusStateWithArgSeedPDA derives the Attestation PDA for the consensus_state_at_height account.
+//
+// Seeds, in order:
+//   - const "consensus_state"
+//   - arg height (msg.height)
 func (attestationPDAs) ConsensusStateWithArgSeedPDA(programID solanago.PublicKey, height []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("consensus_state"), height},
@@ -147,6 +190,10 @@ func (attestationPDAs) ConsensusStateWithArgSeedPDA(programID solanago.PublicKey
 	return pda, bump
 }
 
+// ProgramDataPDA derives the Attestation PDA for the program_data account.
+//
+// Seeds, in order:
+//   - const 0xd05647d84b8aa0591a46b92754e6180d624d8764c677a58eb45db85e6ed1e4bb
 func (attestationPDAs) ProgramDataPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte{0xd0, 0x56, 0x47, 0xd8, 0x4b, 0x8a, 0xa0, 0x59, 0x1a, 0x46, 0xb9, 0x27, 0x54, 0xe6, 0x18, 0x0d, 0x62, 0x4d, 0x87, 0x64, 0xc6, 0x77, 0xa5, 0x8e, 0xb4, 0x5d, 0xb8, 0x5e, 0x6e, 0xd1, 0xe4, 0xbb}},
@@ -158,6 +205,10 @@ func (attestationPDAs) ProgramDataPDA(programID solanago.PublicKey) (solanago.Pu
 	return pda, bump
 }
 
+// AccessManagerPDA derives the Ics07Tendermint PDA for the access_manager account.
+//
+// Seeds, in order:
+//   - const "access_manager"
 func (ics07TendermintPDAs) AccessManagerPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("access_manager")},
@@ -169,6 +220,10 @@ func (ics07TendermintPDAs) AccessManagerPDA(programID solanago.PublicKey) (solan
 	return pda, bump
 }
 
+// AppStatePDA derives the Ics07Tendermint PDA for the app_state account.
+//
+// Seeds, in order:
+//   - const "app_state"
 func (ics07TendermintPDAs) AppStatePDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("app_state")},
@@ -180,6 +235,10 @@ func (ics07TendermintPDAs) AppStatePDA(programID solanago.PublicKey) (solanago.P
 	return pda, bump
 }
 
+// ClientPDA derives the Ics07Tendermint PDA for the client_state account.
+//
+// Seeds, in order:
+//   - const "client"
 func (ics07TendermintPDAs) ClientPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("client")},
@@ -191,6 +250,11 @@ func (ics07TendermintPDAs) ClientPDA(programID solanago.PublicKey) (solanago.Pub
 	return pda, bump
 }
 
+// ConsensusStateWithAccountSeedPDA derives the Ics07Tendermint PDA for the consensus_state account.
+//
+// Seeds, in order:
+//   - const "consensus_state"
+//   - account revisionHeight (client_state.latest_height.revision_height)
 func (ics07TendermintPDAs) ConsensusStateWithAccountSeedPDA(programID solanago.PublicKey, revisionHeight []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("consensus_state"), revisionHeight},
@@ -202,6 +266,11 @@ func (ics07TendermintPDAs) ConsensusStateWithAccountSeedPDA(programID solanago.P
 	return pda, bump
 }
 
+// ConsensusStateWithArgSeedPDA derives the Ics07Tendermint PDA for the consensus_state_store account.
+//
+// Seeds, in order:
+//   - const "consensus_state"
+//   - arg revisionHeight (client_state.latest_height.revision_height)
 func (ics07TendermintPDAs) ConsensusStateWithArgSeedPDA(programID solanago.PublicKey, revisionHeight []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("consensus_state"), revisionHeight},
@@ -213,6 +282,10 @@ func (ics07TendermintPDAs) ConsensusStateWithArgSeedPDA(programID solanago.Publi
 	return pda, bump
 }
 
+// ProgramDataPDA derives the Ics07Tendermint PDA for the program_data account.
+//
+// Seeds, in order:
+//   - const 0xfa206eca520ca6bf0a8f5eed96d3950378d06b55715ae644a3fdf44d840908ec
 func (ics07TendermintPDAs) ProgramDataPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte{0xfa, 0x20, 0x6e, 0xca, 0x52, 0x0c, 0xa6, 0xbf, 0x0a, 0x8f, 0x5e, 0xed, 0x96, 0xd3, 0x95, 0x03, 0x78, 0xd0, 0x6b, 0x55, 0x71, 0x5a, 0xe6, 0x44, 0xa3, 0xfd, 0xf4, 0x4d, 0x84, 0x09, 0x08, 0xec}},
@@ -224,6 +297,10 @@ func (ics07TendermintPDAs) ProgramDataPDA(programID solanago.PublicKey) (solanag
 	return pda, bump
 }
 
+// AccessManagerPDA derives the Ics26Router PDA for the access_manager account.
+//
+// Seeds, in order:
+//   - const "access_manager"
 func (ics26RouterPDAs) AccessManagerPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("access_manager")},
@@ -235,6 +312,11 @@ func (ics26RouterPDAs) AccessManagerPDA(programID solanago.PublicKey) (solanago.
 	return pda, bump
 }
 
+// ClientWithArgSeedPDA derives the Ics26Router PDA for the client account.
+//
+// Seeds, in order:
+//   - const "client"
+//   - arg sourceClient (msg.packet.source_client)
 func (ics26RouterPDAs) ClientWithArgSeedPDA(programID solanago.PublicKey, sourceClient []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("client"), sourceClient},
@@ -246,6 +328,11 @@ func (ics26RouterPDAs) ClientWithArgSeedPDA(programID solanago.PublicKey, source
 	return pda, bump
 }
 
+// IbcAppWithArgSeedPDA derives the Ics26Router PDA for the ibc_app account.
+//
+// Seeds, in order:
+//   - const "ibc_app"
+//   - arg portId (port_id)
 func (ics26RouterPDAs) IbcAppWithArgSeedPDA(programID solanago.PublicKey, portId []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("ibc_app"), portId},
@@ -257,6 +344,12 @@ func (ics26RouterPDAs) IbcAppWithArgSeedPDA(programID solanago.PublicKey, portId
 	return pda, bump
 }
 
+// PacketAckWithArgSeedPDA derives the Ics26Router PDA for the packet_ack account.
+//
+// Seeds, in order:
+//   - const "packet_ack"
+//   - arg destClient (msg.packet.dest_client)
+//   - arg sequence (msg.packet.sequence)
 func (ics26RouterPDAs) PacketAckWithArgSeedPDA(programID solanago.PublicKey, destClient []byte, sequence []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("packet_ack"), destClient, sequence},
@@ -268,6 +361,12 @@ func (ics26RouterPDAs) PacketAckWithArgSeedPDA(programID solanago.PublicKey, des
 	return pda, bump
 }
 
+// PacketCommitmentWithArgSeedPDA derives the Ics26Router PDA for the packet_commitment account.
+//
+// Seeds, in order:
+//   - const "packet_commitment"
+//   - arg sourceClient (msg.packet.source_client)
+//   - arg sequence (msg.packet.sequence)
 func (ics26RouterPDAs) PacketCommitmentWithArgSeedPDA(programID solanago.PublicKey, sourceClient []byte, sequence []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("packet_commitment"), sourceClient, sequence},
@@ -279,6 +378,12 @@ func (ics26RouterPDAs) PacketCommitmentWithArgSeedPDA(programID solanago.PublicK
 	return pda, bump
 }
 
+// PacketReceiptWithArgSeedPDA derives the Ics26Router PDA for the packet_receipt account.
+//
+// Seeds, in order:
+//   - const "packet_receipt"
+//   - arg destClient (msg.packet.dest_client)
+//   - arg sequence (msg.packet.sequence)
 func (ics26RouterPDAs) PacketReceiptWithArgSeedPDA(programID solanago.PublicKey, destClient []byte, sequence []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("packet_receipt"), destClient, sequence},
@@ -290,6 +395,10 @@ func (ics26RouterPDAs) PacketReceiptWithArgSeedPDA(programID solanago.PublicKey,
 	return pda, bump
 }
 
+// ProgramDataPDA derives the Ics26Router PDA for the program_data account.
+//
+// Seeds, in order:
+//   - const 0xd63acac6aba194bdd013259fa32cb468a6a9703364ff0ca7ee9ee6478df03ccd
 func (ics26RouterPDAs) ProgramDataPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte{0xd6, 0x3a, 0xca, 0xc6, 0xab, 0xa1, 0x94, 0xbd, 0xd0, 0x13, 0x25, 0x9f, 0xa3, 0x2c, 0xb4, 0x68, 0xa6, 0xa9, 0x70, 0x33, 0x64, 0xff, 0x0c, 0xa7, 0xee, 0x9e, 0xe6, 0x47, 0x8d, 0xf0, 0x3c, 0xcd}},
@@ -301,6 +410,10 @@ func (ics26RouterPDAs) ProgramDataPDA(programID solanago.PublicKey) (solanago.Pu
 	return pda, bump
 }
 
+// RouterStatePDA derives the Ics26Router PDA for the router_state account.
+//
+// Seeds, in order:
+//   - const "router_state"
 func (ics26RouterPDAs) RouterStatePDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("router_state")},
@@ -312,6 +425,10 @@ func (ics26RouterPDAs) RouterStatePDA(programID solanago.PublicKey) (solanago.Pu
 	return pda, bump
 }
 
+// AccessManagerPDA derives the Ics27Gmp PDA for the access_manager account.
+//
+// Seeds, in order:
+//   - const "access_manager"
 func (ics27GmpPDAs) AccessManagerPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("access_manager")},
@@ -323,6 +440,10 @@ func (ics27GmpPDAs) AccessManagerPDA(programID solanago.PublicKey) (solanago.Pub
 	return pda, bump
 }
 
+// AppStatePDA derives the Ics27Gmp PDA for the app_state account.
+//
+// Seeds, in order:
+//   - const "app_state"
 func (ics27GmpPDAs) AppStatePDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("app_state")},
@@ -334,6 +455,11 @@ func (ics27GmpPDAs) AppStatePDA(programID solanago.PublicKey) (solanago.PublicKe
 	return pda, bump
 }
 
+// ClientWithArgSeedPDA derives the Ics27Gmp PDA for the client account.
+//
+// Seeds, in order:
+//   - const "client"
+//   - arg sourceClient (msg.source_client)
 func (ics27GmpPDAs) ClientWithArgSeedPDA(programID solanago.PublicKey, sourceClient []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("client"), sourceClient},
@@ -345,6 +471,12 @@ func (ics27GmpPDAs) ClientWithArgSeedPDA(programID solanago.PublicKey, sourceCli
 	return pda, bump
 }
 
+// GmpResultWithArgSeedPDA derives the Ics27Gmp PDA for the result_account account.
+//
+// Seeds, in order:
+//   - const "gmp_result"
+//   - arg sourceClient (msg.source_client)
+//   - arg sequence (msg.sequence)
 func (ics27GmpPDAs) GmpResultWithArgSeedPDA(programID solanago.PublicKey, sourceClient []byte, sequence []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("gmp_result"), sourceClient, sequence},
@@ -356,6 +488,11 @@ func (ics27GmpPDAs) GmpResultWithArgSeedPDA(programID solanago.PublicKey, source
 	return pda, bump
 }
 
+// IbcAppGmpportPDA derives the Ics27Gmp PDA for the ibc_app account.
+//
+// Seeds, in order:
+//   - const "ibc_app"
+//   - const "gmpport"
 func (ics27GmpPDAs) IbcAppGmpportPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("ibc_app"), []byte("gmpport")},
@@ -367,6 +504,12 @@ func (ics27GmpPDAs) IbcAppGmpportPDA(programID solanago.PublicKey) (solanago.Pub
 	return pda, bump
 }
 
+// PacketCommitmentWithArgSeedPDA derives the Ics27Gmp PDA for the packet_commitment account.
+//
+// Seeds, in order:
+//   - const "packet_commitment"
+//   - arg sourceClient (msg.source_client)
+//   - arg sequence (msg.sequence)
 func (ics27GmpPDAs) PacketCommitmentWithArgSeedPDA(programID solanago.PublicKey, sourceClient []byte, sequence []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("packet_commitment"), sourceClient, sequence},
@@ -378,6 +521,10 @@ func (ics27GmpPDAs) PacketCommitmentWithArgSeedPDA(programID solanago.PublicKey,
 	return pda, bump
 }
 
+// ProgramDataPDA derives the Ics27Gmp PDA for the program_data account.
+//
+// Seeds, in order:
+//   - const 0x2528427b8b005b212c37b4b0fd5e477130e261dbd4ef22db32f048c16540e519
 func (ics27GmpPDAs) ProgramDataPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte{0x25, 0x28, 0x42, 0x7b, 0x8b, 0x00, 0x5b, 0x21, 0x2c, 0x37, 0xb4, 0xb0, 0xfd, 0x5e, 0x47, 0x71, 0x30, 0xe2, 0x61, 0xdb, 0xd4, 0xef, 0x22, 0xdb, 0x32, 0xf0, 0x48, 0xc1, 0x65, 0x40, 0xe5, 0x19}},
@@ -389,6 +536,10 @@ func (ics27GmpPDAs) ProgramDataPDA(programID solanago.PublicKey) (solanago.Publi
 	return pda, bump
 }
 
+// RouterStatePDA derives the Ics27Gmp PDA for the router_state account.
+//
+// Seeds, in order:
+//   - const "router_state"
 func (ics27GmpPDAs) RouterStatePDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("router_state")},
@@ -400,6 +551,10 @@ func (ics27GmpPDAs) RouterStatePDA(programID solanago.PublicKey) (solanago.Publi
 	return pda, bump
 }
 
+// AppStatePDA derives the Ift PDA for the gmp_app_state account.
+//
+// Seeds, in order:
+//   - const "app_state"
 func (iftPDAs) AppStatePDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("app_state")},
@@ -411,6 +566,12 @@ func (iftPDAs) AppStatePDA(programID solanago.PublicKey) (solanago.PublicKey, ui
 	return pda, bump
 }
 
+// GmpResultWithArgSeedPDA derives the Ift PDA for the gmp_result account.
+//
+// Seeds, in order:
+//   - const "gmp_result"
+//   - arg clientId (client_id)
+//   - arg sequence (sequence)
 func (iftPDAs) GmpResultWithArgSeedPDA(programID solanago.PublicKey, clientId []byte, sequence []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("gmp_result"), clientId, sequence},
@@ -422,6 +583,11 @@ func (iftPDAs) GmpResultWithArgSeedPDA(programID solanago.PublicKey, clientId []
 	return pda, bump
 }
 
+// IftAppMintStateWithAccountSeedPDA derives the Ift PDA for the app_mint_state account.
+//
+// Seeds, in order:
+//   - const "ift_app_mint_state"
+//   - account mint (app_mint_state.mint)
 func (iftPDAs) IftAppMintStateWithAccountSeedPDA(programID solanago.PublicKey, mint []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("ift_app_mint_state"), mint},
@@ -433,6 +599,10 @@ func (iftPDAs) IftAppMintStateWithAccountSeedPDA(programID solanago.PublicKey, m
 	return pda, bump
 }
 
+// IftAppStatePDA derives the Ift PDA for the app_state account.
+//
+// Seeds, in order:
+//   - const "ift_app_state"
 func (iftPDAs) IftAppStatePDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("ift_app_state")},
@@ -444,6 +614,12 @@ func (iftPDAs) IftAppStatePDA(programID solanago.PublicKey) (solanago.PublicKey,
 	return pda, bump
 }
 
+// IftBridgeWithAccountSeedPDA derives the Ift PDA for the ift_bridge account.
+//
+// Seeds, in order:
+//   - const "ift_bridge"
+//   - account mint (app_mint_state.mint)
+//   - account clientId (ift_bridge.client_id)
 func (iftPDAs) IftBridgeWithAccountSeedPDA(programID solanago.PublicKey, mint []byte, clientId []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("ift_bridge"), mint, clientId},
@@ -455,6 +631,12 @@ func (iftPDAs) IftBridgeWithAccountSeedPDA(programID solanago.PublicKey, mint []
 	return pda, bump
 }
 
+// IftBridgeWithArgAndAccountSeedPDA derives the Ift PDA for the ift_bridge account.
+//
+// Seeds, in order:
+//   - const "ift_bridge"
+//   - account mint (app_mint_state.mint)
+//   - arg clientId (client_id)
 func (iftPDAs) IftBridgeWithArgAndAccountSeedPDA(programID solanago.PublicKey, mint []byte, clientId []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("ift_bridge"), mint, clientId},
@@ -466,6 +648,11 @@ func (iftPDAs) IftBridgeWithArgAndAccountSeedPDA(programID solanago.PublicKey, m
 	return pda, bump
 }
 
+// IftMintAuthorityWithAccountSeedPDA derives the Ift PDA for the mint_authority account.
+//
+// Seeds, in order:
+//   - const "ift_mint_authority"
+//   - account mint (mint)
 func (iftPDAs) IftMintAuthorityWithAccountSeedPDA(programID solanago.PublicKey, mint []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("ift_mint_authority"), mint},
@@ -477,6 +664,12 @@ func (iftPDAs) IftMintAuthorityWithAccountSeedPDA(programID solanago.PublicKey,
 	return pda, bump
 }
 
+// PacketCommitmentWithArgSeedPDA derives the Ift PDA for the packet_commitment account.
+//
+// Seeds, in order:
+//   - const "packet_commitment"
+//   - arg clientId (msg.client_id)
+//   - arg sequence (msg.sequence)
 func (iftPDAs) PacketCommitmentWithArgSeedPDA(programID solanago.PublicKey, clientId []byte, sequence []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("packet_commitment"), clientId, sequence},
@@ -488,6 +681,13 @@ func (iftPDAs) PacketCommitmentWithArgSeedPDA(programID solanago.PublicKey, clie
 	return pda, bump
 }
 
+// PendingTransferWithArgAndAccountSeedPDA derives the Ift PDA for the pending_transfer account.
+//
+// Seeds, in order:
+//   - const "pending_transfer"
+//   - account mint (app_mint_state.mint)
+//   - arg clientId (client_id)
+//   - arg sequence (sequence)
 func (iftPDAs) PendingTransferWithArgAndAccountSeedPDA(programID solanago.PublicKey, mint []byte, clientId []byte, sequence []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("pending_transfer"), mint, clientId, sequence},
@@ -499,6 +699,10 @@ func (iftPDAs) PendingTransferWithArgAndAccountSeedPDA(programID solanago.Public
 	return pda, bump
 }
 
+// ProgramDataPDA derives the Ift PDA for the program_data account.
+//
+// Seeds, in order:
+//   - const 0xb84f41f3a62f4a018f54a5494fdf323f96dba0ee5a7afc0d967418f9fe9d891a
 func (iftPDAs) ProgramDataPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte{0xb8, 0x4f, 0x41, 0xf3, 0xa6, 0x2f, 0x4a, 0x01, 0x8f, 0x54, 0xa5, 0x49, 0x4f, 0xdf, 0x32, 0x3f, 0x96, 0xdb, 0xa0, 0xee, 0x5a, 0x7a, 0xfc, 0x0d, 0x96, 0x74, 0x18, 0xf9, 0xfe, 0x9d, 0x89, 0x1a}},
@@ -510,6 +714,12 @@ func (iftPDAs) ProgramDataPDA(programID solanago.PublicKey) (solanago.PublicKey,
 	return pda, bump
 }
 
+// ReceiverTokenAccountWithAccountSeedPDA derives the Ift PDA for the receiver_token_account account.
+//
+// Seeds, in order:
+//   - account receiverOwner (receiver_owner)
+//   - account tokenProgram (token_program)
+//   - account mint (mint)
 func (iftPDAs) ReceiverTokenAccountWithAccountSeedPDA(programID solanago.PublicKey, receiverOwner []byte, tokenProgram []byte, mint []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{receiverOwner, tokenProgram, mint},
@@ -521,6 +731,11 @@ func (iftPDAs) ReceiverTokenAccountWithAccountSeedPDA(programID solanago.PublicK
 	return pda, bump
 }
 
+// ClientWithArgSeedPDA derives the MockLightClient PDA for the client_state account.
+//
+// Seeds, in order:
+//   - const "client"
+//   - arg chainId (chain_id)
 func (mockLightClientPDAs) ClientWithArgSeedPDA(programID solanago.PublicKey, chainId []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("client"), chainId},
@@ -532,6 +747,12 @@ func (mockLightClientPDAs) ClientWithArgSeedPDA(programID solanago.PublicKey, ch
 	return pda, bump
 }
 
+// ConsensusStateWithArgSeedPDA derives the MockLightClient PDA for the consensus_state_store account.
+//
+// Seeds, in order:
+//   - const "consensus_state"
+//   - arg clientState (client_state)
+//   - arg latestHeight (latest_height)
 func (mockLightClientPDAs) ConsensusStateWithArgSeedPDA(programID solanago.PublicKey, clientState []byte, latestHeight []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("consensus_state"), clientState, latestHeight},
@@ -543,6 +764,10 @@ func (mockLightClientPDAs) ConsensusStateWithArgSeedPDA(programID solanago.Publi
 	return pda, bump
 }
 
+// CpiResultPDA derives the TestCpiTarget PDA for the result account.
+//
+// Seeds, in order:
+//   - const "cpi_result"
 func (testCpiTargetPDAs) CpiResultPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("cpi_result")},
@@ -554,6 +779,10 @@ func (testCpiTargetPDAs) CpiResultPDA(programID solanago.PublicKey) (solanago.Pu
 	return pda, bump
 }
 
+// CounterAppStatePDA derives the TestGmpApp PDA for the app_state account.
+//
+// Seeds, in order:
+//   - const "counter_app_state"
 func (testGmpAppPDAs) CounterAppStatePDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("counter_app_state")},
@@ -565,6 +794,11 @@ func (testGmpAppPDAs) CounterAppStatePDA(programID solanago.PublicKey) (solanago
 	return pda, bump
 }
 
+// UserCounterWithAccountSeedPDA derives the TestGmpApp PDA for the user_counter account.
+//
+// Seeds, in order:
+//   - const "user_counter"
+//   - account userAuthority (user_authority)
 func (testGmpAppPDAs) UserCounterWithAccountSeedPDA(programID solanago.PublicKey, userAuthority []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("user_counter"), userAuthority},
@@ -576,6 +810,11 @@ func (testGmpAppPDAs) UserCounterWithAccountSeedPDA(programID solanago.PublicKey
 	return pda, bump
 }
 
+// UserCounterWithArgSeedPDA derives the TestGmpApp PDA for the user_counter account.
+//
+// Seeds, in order:
+//   - const "user_counter"
+//   - arg user (user)
 func (testGmpAppPDAs) UserCounterWithArgSeedPDA(programID solanago.PublicKey, user []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("user_counter"), user},
@@ -587,6 +826,10 @@ func (testGmpAppPDAs) UserCounterWithArgSeedPDA(programID solanago.PublicKey, us
 	return pda, bump
 }
 
+// AppStatePDA derives the TestIbcApp PDA for the app_state account.
+//
+// Seeds, in order:
+//   - const "app_state"
 func (testIbcAppPDAs) AppStatePDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("app_state")},
@@ -598,6 +841,11 @@ func (testIbcAppPDAs) AppStatePDA(programID solanago.PublicKey) (solanago.Public
 	return pda, bump
 }
 
+// ClientWithArgSeedPDA derives the TestIbcApp PDA for the client account.
+//
+// Seeds, in order:
+//   - const "client"
+//   - arg sourceClient (msg.source_client)
 func (testIbcAppPDAs) ClientWithArgSeedPDA(programID solanago.PublicKey, sourceClient []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("client"), sourceClient},
@@ -609,6 +857,11 @@ func (testIbcAppPDAs) ClientWithArgSeedPDA(programID solanago.PublicKey, sourceC
 	return pda, bump
 }
 
+// EscrowStateWithArgSeedPDA derives the TestIbcApp PDA for the escrow_state account.
+//
+// Seeds, in order:
+//   - const "escrow_state"
+//   - arg sourceClient (msg.source_client)
 func (testIbcAppPDAs) EscrowStateWithArgSeedPDA(programID solanago.PublicKey, sourceClient []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("escrow_state"), sourceClient},
@@ -620,6 +873,11 @@ func (testIbcAppPDAs) EscrowStateWithArgSeedPDA(programID solanago.PublicKey, so
 	return pda, bump
 }
 
+// EscrowWithArgSeedPDA derives the TestIbcApp PDA for the escrow_account account.
+//
+// Seeds, in order:
+//   - const "escrow"
+//   - arg sourceClient (msg.source_client)
 func (testIbcAppPDAs) EscrowWithArgSeedPDA(programID solanago.PublicKey, sourceClient []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("escrow"), sourceClient},
@@ -631,6 +889,11 @@ func (testIbcAppPDAs) EscrowWithArgSeedPDA(programID solanago.PublicKey, sourceC
 	return pda, bump
 }
 
+// IbcAppTransferPDA derives the TestIbcApp PDA for the ibc_app account.
+//
+// Seeds, in order:
+//   - const "ibc_app"
+//   - const "transfer"
 func (testIbcAppPDAs) IbcAppTransferPDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("ibc_app"), []byte("transfer")},
@@ -642,6 +905,11 @@ func (testIbcAppPDAs) IbcAppTransferPDA(programID solanago.PublicKey) (solanago.
 	return pda, bump
 }
 
+// IbcAppWithArgSeedPDA derives the TestIbcApp PDA for the ibc_app account.
+//
+// Seeds, in order:
+//   - const "ibc_app"
+//   - arg sourcePort (msg.source_port)
 func (testIbcAppPDAs) IbcAppWithArgSeedPDA(programID solanago.PublicKey, sourcePort []byte) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("ibc_app"), sourcePort},
@@ -653,6 +921,10 @@ func (testIbcAppPDAs) IbcAppWithArgSeedPDA(programID solanago.PublicKey, sourceP
 	return pda, bump
 }
 
+// RouterStatePDA derives the TestIbcApp PDA for the router_state account.
+//
+// Seeds, in order:
+//   - const "router_state"
 func (testIbcAppPDAs) RouterStatePDA(programID solanago.PublicKey) (solanago.PublicKey, uint8) {
 	pda, bump, err := solanago.FindProgramAddress(
 		[][]byte{[]byte("router_state")},
@@ -0,0 +1,78 @@
+// Package height provides a single Height type for the IICS02ClientMsgsHeight struct that abigen
+// generates separately in every binding, together with conversions to and from ibc-go heights.
+//
+// Binding heights share the underlying type of Height, so they convert with a plain type conversion:
+//
+//	h := height.Height(msg.ProofHeight)
+//	msg.ProofHeight = ics26router.IICS02ClientMsgsHeight(h)
+package height
+
+import "fmt"
+
+// Height is a revision number and a height within that revision, ordered like ibc-go's clienttypes.Height.
+type Height struct {
+	RevisionNumber uint64
+	RevisionHeight uint64
+}
+
+// IBCHeight is implemented by ibc-go's clienttypes.Height, and by any exported.Height.
+type IBCHeight interface {
+	GetRevisionNumber() uint64
+	GetRevisionHeight() uint64
+}
+
+// FromIBCHeight converts an ibc-go height.
+func FromIBCHeight(h IBCHeight) Height {
+	return Height{
+		RevisionNumber: h.GetRevisionNumber(),
+		RevisionHeight: h.GetRevisionHeight(),
+	}
+}
+
+// ToIBCHeight converts h using newHeight, which is meant to be clienttypes.NewHeight. Taking the
+// constructor keeps ibc-go out of the bindings' dependencies.
+func ToIBCHeight[T any](h Height, newHeight func(revisionNumber, revisionHeight uint64) T) T {
+	return newHeight(h.RevisionNumber, h.RevisionHeight)
+}
+
+// IsZero reports whether both the revision number and the revision height are zero.
+func (h Height) IsZero() bool {
+	return h.RevisionNumber == 0 && h.RevisionHeight == 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether h is lower than, equal to or greater than other.
+// Revision numbers are compared first, then revision heights.
+func (h Height) Compare(other Height) int {
+	return Compare(h, other)
+}
+
+// LT reports whether h is lower than other.
+func (h Height) LT(other Height) bool {
+	return Compare(h, other) < 0
+}
+
+// GT reports whether h is greater than other.
+func (h Height) GT(other Height) bool {
+	return Compare(h, other) > 0
+}
+
+// String formats the height as {revision number}-{revision height}, like ibc-go.
+func (h Height) String() string {
+	return fmt.Sprintf("%d-%d", h.RevisionNumber, h.RevisionHeight)
+}
+
+// Compare orders heights like Height.Compare, for use with slices.SortFunc.
+func Compare(a, b Height) int {
+	switch {
+	case a.RevisionNumber < b.RevisionNumber:
+		return -1
+	case a.RevisionNumber > b.RevisionNumber:
+		return 1
+	case a.RevisionHeight < b.RevisionHeight:
+		return -1
+	case a.RevisionHeight > b.RevisionHeight:
+		return 1
+	default:
+		return 0
+	}
+}
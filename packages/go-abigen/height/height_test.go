@@ -0,0 +1,80 @@
+package height
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/ics26router"
+	"github.com/cosmos/solidity-ibc-eureka/packages/go-abigen/sp1ics07tendermint"
+)
+
+// ibcHeight stands in for ibc-go's clienttypes.Height, which has the same accessors and constructor
+type ibcHeight struct {
+	revisionNumber uint64
+	revisionHeight uint64
+}
+
+func newIBCHeight(revisionNumber, revisionHeight uint64) ibcHeight {
+	return ibcHeight{revisionNumber: revisionNumber, revisionHeight: revisionHeight}
+}
+
+func (h ibcHeight) GetRevisionNumber() uint64 { return h.revisionNumber }
+func (h ibcHeight) GetRevisionHeight() uint64 { return h.revisionHeight }
+
+func TestIBCHeightConversion(t *testing.T) {
+	h := Height{RevisionNumber: 1, RevisionHeight: 100}
+
+	converted := ToIBCHeight(h, newIBCHeight)
+	require.Equal(t, newIBCHeight(1, 100), converted)
+	require.Equal(t, h, FromIBCHeight(converted))
+
+	require.Equal(t, Height{}, FromIBCHeight(ToIBCHeight(Height{}, newIBCHeight)))
+}
+
+func TestBindingConversion(t *testing.T) {
+	routerHeight := ics26router.IICS02ClientMsgsHeight{RevisionNumber: 2, RevisionHeight: 7}
+
+	h := Height(routerHeight)
+	require.Equal(t, Height{RevisionNumber: 2, RevisionHeight: 7}, h)
+	require.Equal(t, sp1ics07tendermint.IICS02ClientMsgsHeight{RevisionNumber: 2, RevisionHeight: 7}, sp1ics07tendermint.IICS02ClientMsgsHeight(h))
+}
+
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		name string
+		a, b Height
+		exp  int
+	}{
+		{name: "equal", a: Height{1, 10}, b: Height{1, 10}, exp: 0},
+		{name: "zero equal", a: Height{}, b: Height{}, exp: 0},
+		{name: "lower height", a: Height{1, 9}, b: Height{1, 10}, exp: -1},
+		{name: "higher height", a: Height{1, 11}, b: Height{1, 10}, exp: 1},
+		{name: "revision number wins over height", a: Height{0, 1000}, b: Height{1, 1}, exp: -1},
+		{name: "higher revision number", a: Height{2, 0}, b: Height{1, 1000}, exp: 1},
+		{name: "zero is lowest", a: Height{}, b: Height{0, 1}, exp: -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.exp, tc.a.Compare(tc.b))
+			require.Equal(t, -tc.exp, tc.b.Compare(tc.a))
+			require.Equal(t, tc.exp < 0, tc.a.LT(tc.b))
+			require.Equal(t, tc.exp > 0, tc.a.GT(tc.b))
+			require.Equal(t, tc.exp == 0, tc.a == tc.b)
+		})
+	}
+
+	heights := []Height{{1, 5}, {0, 7}, {1, 2}, {}, {2, 0}}
+	slices.SortFunc(heights, Compare)
+	require.Equal(t, []Height{{}, {0, 7}, {1, 2}, {1, 5}, {2, 0}}, heights)
+}
+
+func TestIsZero(t *testing.T) {
+	require.True(t, Height{}.IsZero())
+	require.False(t, Height{RevisionHeight: 1}.IsZero())
+	require.False(t, Height{RevisionNumber: 1}.IsZero())
+	require.Equal(t, "0-0", Height{}.String())
+	require.Equal(t, "1-100", Height{1, 100}.String())
+}
@@ -0,0 +1,84 @@
+package ics26router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// UpdateResult mirrors ILightClientMsgs.UpdateResult, the result of a client update reported by the
+// ICS02ClientUpdated event.
+type UpdateResult uint8
+
+const (
+	// UpdateResultUpdate is reported when the client was updated
+	UpdateResultUpdate UpdateResult = iota
+	// UpdateResultMisbehaviour is reported when the update was found to be misbehaviour
+	UpdateResultMisbehaviour
+	// UpdateResultNoOp is reported when the client was already up to date
+	UpdateResultNoOp
+)
+
+var (
+	// ErrUpdateClientReverted is returned for an updateClient transaction that reverted
+	ErrUpdateClientReverted = errors.New("update client transaction reverted")
+	// ErrNoClientUpdate is returned for a successful transaction without an ICS02ClientUpdated event of the router
+	ErrNoClientUpdate = errors.New("no ICS02ClientUpdated event in transaction")
+)
+
+// String returns the Solidity name of the result.
+func (r UpdateResult) String() string {
+	switch r {
+	case UpdateResultUpdate:
+		return "Update"
+	case UpdateResultMisbehaviour:
+		return "Misbehaviour"
+	case UpdateResultNoOp:
+		return "NoOp"
+	default:
+		return fmt.Sprintf("UpdateResult(%d)", uint8(r))
+	}
+}
+
+// WaitForClientUpdate waits for the receipt of the transaction txHash, sent to the router at the given
+// address, and returns the result of the first client update it reports. A multicall updating several
+// clients should filter the receipt logs with ParseICS02ClientUpdated instead.
+func WaitForClientUpdate(ctx context.Context, backend bind.DeployBackend, router common.Address, txHash common.Hash) (UpdateResult, error) {
+	receipt, err := bind.WaitMinedHash(ctx, backend, txHash)
+	if err != nil {
+		return 0, fmt.Errorf("waiting for receipt of %s: %w", txHash, err)
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return 0, fmt.Errorf("%w: %s", ErrUpdateClientReverted, txHash)
+	}
+
+	parsed, err := ContractMetaData.GetAbi()
+	if err != nil {
+		return 0, err
+	}
+	eventID := parsed.Events["ICS02ClientUpdated"].ID
+
+	filterer, err := NewContractFilterer(router, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, log := range receipt.Logs {
+		if log.Address != router || len(log.Topics) == 0 || log.Topics[0] != eventID {
+			continue
+		}
+
+		event, err := filterer.ParseICS02ClientUpdated(*log)
+		if err != nil {
+			return 0, fmt.Errorf("parsing ICS02ClientUpdated in %s: %w", txHash, err)
+		}
+		return UpdateResult(event.Result), nil
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrNoClientUpdate, txHash)
+}
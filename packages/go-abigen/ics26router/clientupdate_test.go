@@ -0,0 +1,110 @@
+package ics26router
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// receiptBackend is a bind.DeployBackend serving the receipts of mined transactions, others are not found
+type receiptBackend struct {
+	bind.DeployBackend
+	receipts map[common.Hash]*types.Receipt
+}
+
+func (b receiptBackend) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, ok := b.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func TestWaitForClientUpdate(t *testing.T) {
+	parsed, err := ContractMetaData.GetAbi()
+	require.NoError(t, err)
+	router := common.HexToAddress("0x01")
+	other := common.HexToAddress("0x02")
+
+	clientUpdatedLog := func(address common.Address, result UpdateResult) *types.Log {
+		ev := parsed.Events["ICS02ClientUpdated"]
+		packed, err := ev.Inputs.NonIndexed().Pack("client-0", uint8(result))
+		require.NoError(t, err)
+		return &types.Log{Address: address, Topics: []common.Hash{ev.ID}, Data: packed}
+	}
+	sendPacketLog := packetLog(t, parsed, "SendPacket", 1, 0, IICS26RouterMsgsPacket{})
+	sendPacketLog.Address = router
+
+	testCases := []struct {
+		name     string
+		receipt  *types.Receipt
+		expected UpdateResult
+		err      error
+	}{
+		{
+			name:     "update",
+			receipt:  &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{clientUpdatedLog(router, UpdateResultUpdate)}},
+			expected: UpdateResultUpdate,
+		},
+		{
+			name:     "misbehaviour",
+			receipt:  &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{clientUpdatedLog(router, UpdateResultMisbehaviour)}},
+			expected: UpdateResultMisbehaviour,
+		},
+		{
+			name: "no-op after unrelated logs",
+			receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{
+				&sendPacketLog,
+				clientUpdatedLog(other, UpdateResultUpdate),
+				clientUpdatedLog(router, UpdateResultNoOp),
+			}},
+			expected: UpdateResultNoOp,
+		},
+		{
+			name:    "reverted",
+			receipt: &types.Receipt{Status: types.ReceiptStatusFailed},
+			err:     ErrUpdateClientReverted,
+		},
+		{
+			name:    "event of another contract",
+			receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{clientUpdatedLog(other, UpdateResultUpdate)}},
+			err:     ErrNoClientUpdate,
+		},
+		{
+			name: "pending",
+			err:  context.DeadlineExceeded,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			txHash := common.BigToHash(big.NewInt(int64(i + 1)))
+			backend := receiptBackend{receipts: map[common.Hash]*types.Receipt{}}
+			if tc.receipt != nil {
+				backend.receipts[txHash] = tc.receipt
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			result, err := WaitForClientUpdate(ctx, backend, router, txHash)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				require.ErrorContains(t, err, txHash.Hex())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+
+	require.Equal(t, "Misbehaviour", UpdateResultMisbehaviour.String())
+	require.Equal(t, "UpdateResult(3)", UpdateResult(3).String())
+}
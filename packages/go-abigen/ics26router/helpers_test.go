@@ -0,0 +1,38 @@
+package ics26router
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// noSendBackend satisfies bind.ContractTransactor for fully specified NoSend transactions,
+// which never reach the backend
+type noSendBackend struct {
+	bind.ContractTransactor
+}
+
+// newNoSendTransactor returns a transactor on noSendBackend together with fully specified NoSend options,
+// so that calls return the unsigned transaction they would send
+func newNoSendTransactor(t *testing.T) (*ContractTransactor, *bind.TransactOpts) {
+	t.Helper()
+
+	transactor, err := NewContractTransactor(common.HexToAddress("0x01"), noSendBackend{})
+	require.NoError(t, err)
+
+	opts := &bind.TransactOpts{
+		From:     common.HexToAddress("0x02"),
+		Nonce:    big.NewInt(0),
+		GasPrice: big.NewInt(1),
+		GasLimit: 100_000,
+		NoSend:   true,
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return tx, nil
+		},
+	}
+	return transactor, opts
+}
@@ -0,0 +1,107 @@
+package ics26router
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrEmptyProof      = errors.New("proof is empty")
+	ErrZeroProofHeight = errors.New("proof height is zero")
+	ErrEmptyPayloads   = errors.New("packet has no payloads")
+	ErrEmptyClientID   = errors.New("packet client id is empty")
+	ErrTimeoutElapsed  = errors.New("packet timeout timestamp is not in the future")
+	ErrZeroTimeout     = errors.New("packet timeout timestamp is zero")
+	ErrEmptyPrefix     = errors.New("counterparty merkle prefix is empty")
+)
+
+// DefaultMerklePrefix returns the merkle prefix of an ibc-go counterparty, which commits
+// under the "ibc" store key with an empty key prefix.
+func DefaultMerklePrefix() [][]byte {
+	return [][]byte{[]byte("ibc"), []byte("")}
+}
+
+// DefaultCounterpartyInfo builds the counterparty info for AddClient. When no prefix is
+// given, DefaultMerklePrefix is used.
+func DefaultCounterpartyInfo(clientId string, prefix ...[]byte) IICS02ClientMsgsCounterpartyInfo {
+	if len(prefix) == 0 {
+		prefix = DefaultMerklePrefix()
+	}
+
+	return IICS02ClientMsgsCounterpartyInfo{
+		ClientId:     clientId,
+		MerklePrefix: prefix,
+	}
+}
+
+// ValidateCounterpartyInfo performs the checks AddClient would otherwise only surface as
+// an InvalidMerklePrefix revert.
+func ValidateCounterpartyInfo(info IICS02ClientMsgsCounterpartyInfo) error {
+	if len(info.MerklePrefix) == 0 {
+		return ErrEmptyPrefix
+	}
+
+	return nil
+}
+
+// ValidateRecvPacket performs the stateless checks that the router would otherwise only
+// surface as an on-chain revert. The timeout is checked against now, in unix seconds.
+func ValidateRecvPacket(msg IICS26RouterMsgsMsgRecvPacket, now time.Time) error {
+	if len(msg.ProofCommitment) == 0 {
+		return ErrEmptyProof
+	}
+	if msg.ProofHeight.RevisionNumber == 0 && msg.ProofHeight.RevisionHeight == 0 {
+		return ErrZeroProofHeight
+	}
+
+	return validatePacket(msg.Packet, now)
+}
+
+// PacketFromSendEvent returns the packet emitted by a SendPacket event, as needed to time it out.
+// The payload values are copied so the packet does not alias the decoded log.
+func PacketFromSendEvent(ev *ContractSendPacket) IICS26RouterMsgsPacket {
+	packet := ev.Packet
+	packet.Payloads = make([]IICS26RouterMsgsPayload, len(ev.Packet.Payloads))
+	for i, payload := range ev.Packet.Payloads {
+		payload.Value = bytes.Clone(payload.Value)
+		packet.Payloads[i] = payload
+	}
+
+	return packet
+}
+
+// NewMsgTimeoutPacket builds the timeout message for the packet of a SendPacket event, given the proof
+// of non-receipt on the destination chain and the height it was taken at.
+func NewMsgTimeoutPacket(ev *ContractSendPacket, proofTimeout []byte, proofHeight IICS02ClientMsgsHeight) (IICS26RouterMsgsMsgTimeoutPacket, error) {
+	if len(proofTimeout) == 0 {
+		return IICS26RouterMsgsMsgTimeoutPacket{}, ErrEmptyProof
+	}
+	if proofHeight.RevisionNumber == 0 && proofHeight.RevisionHeight == 0 {
+		return IICS26RouterMsgsMsgTimeoutPacket{}, ErrZeroProofHeight
+	}
+
+	return IICS26RouterMsgsMsgTimeoutPacket{
+		Packet:       PacketFromSendEvent(ev),
+		ProofTimeout: proofTimeout,
+		ProofHeight:  proofHeight,
+	}, nil
+}
+
+func validatePacket(packet IICS26RouterMsgsPacket, now time.Time) error {
+	if packet.SourceClient == "" || packet.DestClient == "" {
+		return fmt.Errorf("%w: source %q, destination %q", ErrEmptyClientID, packet.SourceClient, packet.DestClient)
+	}
+	if len(packet.Payloads) == 0 {
+		return ErrEmptyPayloads
+	}
+	if packet.TimeoutTimestamp == 0 {
+		return ErrZeroTimeout
+	}
+	if packet.TimeoutTimestamp <= uint64(now.Unix()) {
+		return fmt.Errorf("%w: timeout %d, now %d", ErrTimeoutElapsed, packet.TimeoutTimestamp, now.Unix())
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package ics26router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func validRecvPacketMsg(now time.Time) IICS26RouterMsgsMsgRecvPacket {
+	return IICS26RouterMsgsMsgRecvPacket{
+		Packet: IICS26RouterMsgsPacket{
+			Sequence:         1,
+			SourceClient:     "07-tendermint-0",
+			DestClient:       "client-0",
+			TimeoutTimestamp: uint64(now.Add(time.Hour).Unix()),
+			Payloads: []IICS26RouterMsgsPayload{{
+				SourcePort: "transfer",
+				DestPort:   "transfer",
+				Version:    "ics20-1",
+				Encoding:   "application/x-solidity-abi",
+				Value:      []byte("value"),
+			}},
+		},
+		ProofCommitment: []byte("proof"),
+		ProofHeight:     IICS02ClientMsgsHeight{RevisionNumber: 1, RevisionHeight: 100},
+	}
+}
+
+func TestValidateRecvPacket(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name     string
+		malleate func(msg *IICS26RouterMsgsMsgRecvPacket)
+		expErr   error
+	}{
+		{
+			name:     "success",
+			malleate: func(*IICS26RouterMsgsMsgRecvPacket) {},
+			expErr:   nil,
+		},
+		{
+			name:     "success: zero revision number",
+			malleate: func(msg *IICS26RouterMsgsMsgRecvPacket) { msg.ProofHeight.RevisionNumber = 0 },
+			expErr:   nil,
+		},
+		{
+			name:     "failure: empty proof",
+			malleate: func(msg *IICS26RouterMsgsMsgRecvPacket) { msg.ProofCommitment = nil },
+			expErr:   ErrEmptyProof,
+		},
+		{
+			name:     "failure: zero proof height",
+			malleate: func(msg *IICS26RouterMsgsMsgRecvPacket) { msg.ProofHeight = IICS02ClientMsgsHeight{} },
+			expErr:   ErrZeroProofHeight,
+		},
+		{
+			name:     "failure: empty source client",
+			malleate: func(msg *IICS26RouterMsgsMsgRecvPacket) { msg.Packet.SourceClient = "" },
+			expErr:   ErrEmptyClientID,
+		},
+		{
+			name:     "failure: empty destination client",
+			malleate: func(msg *IICS26RouterMsgsMsgRecvPacket) { msg.Packet.DestClient = "" },
+			expErr:   ErrEmptyClientID,
+		},
+		{
+			name:     "failure: no payloads",
+			malleate: func(msg *IICS26RouterMsgsMsgRecvPacket) { msg.Packet.Payloads = nil },
+			expErr:   ErrEmptyPayloads,
+		},
+		{
+			name:     "failure: zero timeout",
+			malleate: func(msg *IICS26RouterMsgsMsgRecvPacket) { msg.Packet.TimeoutTimestamp = 0 },
+			expErr:   ErrZeroTimeout,
+		},
+		{
+			name:     "failure: timeout equal to now",
+			malleate: func(msg *IICS26RouterMsgsMsgRecvPacket) { msg.Packet.TimeoutTimestamp = uint64(now.Unix()) },
+			expErr:   ErrTimeoutElapsed,
+		},
+		{
+			name:     "failure: timeout in the past",
+			malleate: func(msg *IICS26RouterMsgsMsgRecvPacket) { msg.Packet.TimeoutTimestamp = uint64(now.Add(-time.Minute).Unix()) },
+			expErr:   ErrTimeoutElapsed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := validRecvPacketMsg(now)
+			tc.malleate(&msg)
+
+			err := ValidateRecvPacket(msg, now)
+			if tc.expErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.expErr)
+		})
+	}
+}
+
+func TestDefaultCounterpartyInfo(t *testing.T) {
+	info := DefaultCounterpartyInfo("07-tendermint-0")
+	require.Equal(t, "07-tendermint-0", info.ClientId)
+	require.Equal(t, [][]byte{[]byte("ibc"), []byte("")}, info.MerklePrefix)
+	require.NoError(t, ValidateCounterpartyInfo(info))
+
+	info = DefaultCounterpartyInfo("08-wasm-0", []byte("wasm"), []byte("prefix"))
+	require.Equal(t, "08-wasm-0", info.ClientId)
+	require.Equal(t, [][]byte{[]byte("wasm"), []byte("prefix")}, info.MerklePrefix)
+	require.NoError(t, ValidateCounterpartyInfo(info))
+
+	// mutating one default must not leak into the next
+	DefaultCounterpartyInfo("07-tendermint-0").MerklePrefix[0][0] = 'x'
+	require.Equal(t, []byte("ibc"), DefaultCounterpartyInfo("07-tendermint-0").MerklePrefix[0])
+
+	err := ValidateCounterpartyInfo(IICS02ClientMsgsCounterpartyInfo{ClientId: "07-tendermint-0"})
+	require.ErrorIs(t, err, ErrEmptyPrefix)
+}
+
+func TestNewMsgTimeoutPacket(t *testing.T) {
+	parsed, err := ContractMetaData.GetAbi()
+	require.NoError(t, err)
+	filterer, err := NewContractFilterer(common.HexToAddress("0x01"), newLogBackend())
+	require.NoError(t, err)
+
+	packet := validRecvPacketMsg(time.Unix(1_700_000_000, 0)).Packet
+	ev, err := filterer.ParseSendPacket(packetLog(t, parsed, "SendPacket", 1, 0, packet))
+	require.NoError(t, err)
+
+	require.True(t, PacketEqual(packet, PacketFromSendEvent(ev)))
+	require.Equal(t, PacketCommitment(packet), PacketCommitment(PacketFromSendEvent(ev)))
+
+	proofHeight := IICS02ClientMsgsHeight{RevisionNumber: 1, RevisionHeight: 200}
+	msg, err := NewMsgTimeoutPacket(ev, []byte("proof"), proofHeight)
+	require.NoError(t, err)
+
+	// The message round-trips through the timeoutPacket calldata
+	calldata, err := parsed.Pack("timeoutPacket", msg)
+	require.NoError(t, err)
+	args, err := parsed.Methods["timeoutPacket"].Inputs.Unpack(calldata[4:])
+	require.NoError(t, err)
+	require.Len(t, args, 1)
+	decoded := *abi.ConvertType(args[0], new(IICS26RouterMsgsMsgTimeoutPacket)).(*IICS26RouterMsgsMsgTimeoutPacket)
+	require.True(t, PacketEqual(packet, decoded.Packet))
+	require.Equal(t, []byte("proof"), decoded.ProofTimeout)
+	require.Equal(t, proofHeight, decoded.ProofHeight)
+
+	// The packet does not alias the event
+	msg.Packet.Payloads[0].Value[0] = 'x'
+	require.Equal(t, []byte("value"), ev.Packet.Payloads[0].Value)
+
+	_, err = NewMsgTimeoutPacket(ev, nil, proofHeight)
+	require.ErrorIs(t, err, ErrEmptyProof)
+	_, err = NewMsgTimeoutPacket(ev, []byte("proof"), IICS02ClientMsgsHeight{})
+	require.ErrorIs(t, err, ErrZeroProofHeight)
+}
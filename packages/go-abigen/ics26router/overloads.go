@@ -0,0 +1,67 @@
+package ics26router
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// AddClientWithId sends the addClient overload registering the client under the given client ID (AddClient).
+func (_Contract *ContractTransactor) AddClientWithId(opts *bind.TransactOpts, clientId string, counterpartyInfo IICS02ClientMsgsCounterpartyInfo, client common.Address) (*types.Transaction, error) {
+	return _Contract.AddClient(opts, clientId, counterpartyInfo, client)
+}
+
+// AddClientWithId sends the addClient overload registering the client under the given client ID using the session's transact options.
+func (_Contract *ContractSession) AddClientWithId(clientId string, counterpartyInfo IICS02ClientMsgsCounterpartyInfo, client common.Address) (*types.Transaction, error) {
+	return _Contract.Contract.AddClientWithId(&_Contract.TransactOpts, clientId, counterpartyInfo, client)
+}
+
+// AddClientWithId sends the addClient overload registering the client under the given client ID using the session's transact options.
+func (_Contract *ContractTransactorSession) AddClientWithId(clientId string, counterpartyInfo IICS02ClientMsgsCounterpartyInfo, client common.Address) (*types.Transaction, error) {
+	return _Contract.Contract.AddClientWithId(&_Contract.TransactOpts, clientId, counterpartyInfo, client)
+}
+
+// AddClientAutoId sends the addClient overload letting the router generate the client ID (AddClient0).
+func (_Contract *ContractTransactor) AddClientAutoId(opts *bind.TransactOpts, counterpartyInfo IICS02ClientMsgsCounterpartyInfo, client common.Address) (*types.Transaction, error) {
+	return _Contract.AddClient0(opts, counterpartyInfo, client)
+}
+
+// AddClientAutoId sends the addClient overload letting the router generate the client ID using the session's transact options.
+func (_Contract *ContractSession) AddClientAutoId(counterpartyInfo IICS02ClientMsgsCounterpartyInfo, client common.Address) (*types.Transaction, error) {
+	return _Contract.Contract.AddClientAutoId(&_Contract.TransactOpts, counterpartyInfo, client)
+}
+
+// AddClientAutoId sends the addClient overload letting the router generate the client ID using the session's transact options.
+func (_Contract *ContractTransactorSession) AddClientAutoId(counterpartyInfo IICS02ClientMsgsCounterpartyInfo, client common.Address) (*types.Transaction, error) {
+	return _Contract.Contract.AddClientAutoId(&_Contract.TransactOpts, counterpartyInfo, client)
+}
+
+// AddIBCAppWithPortId sends the addIBCApp overload registering the app under the given port ID (AddIBCApp0).
+func (_Contract *ContractTransactor) AddIBCAppWithPortId(opts *bind.TransactOpts, portId string, app common.Address) (*types.Transaction, error) {
+	return _Contract.AddIBCApp0(opts, portId, app)
+}
+
+// AddIBCAppWithPortId sends the addIBCApp overload registering the app under the given port ID using the session's transact options.
+func (_Contract *ContractSession) AddIBCAppWithPortId(portId string, app common.Address) (*types.Transaction, error) {
+	return _Contract.Contract.AddIBCAppWithPortId(&_Contract.TransactOpts, portId, app)
+}
+
+// AddIBCAppWithPortId sends the addIBCApp overload registering the app under the given port ID using the session's transact options.
+func (_Contract *ContractTransactorSession) AddIBCAppWithPortId(portId string, app common.Address) (*types.Transaction, error) {
+	return _Contract.Contract.AddIBCAppWithPortId(&_Contract.TransactOpts, portId, app)
+}
+
+// AddIBCAppAutoPortId sends the addIBCApp overload using the app address as port ID (AddIBCApp).
+func (_Contract *ContractTransactor) AddIBCAppAutoPortId(opts *bind.TransactOpts, app common.Address) (*types.Transaction, error) {
+	return _Contract.AddIBCApp(opts, app)
+}
+
+// AddIBCAppAutoPortId sends the addIBCApp overload using the app address as port ID using the session's transact options.
+func (_Contract *ContractSession) AddIBCAppAutoPortId(app common.Address) (*types.Transaction, error) {
+	return _Contract.Contract.AddIBCAppAutoPortId(&_Contract.TransactOpts, app)
+}
+
+// AddIBCAppAutoPortId sends the addIBCApp overload using the app address as port ID using the session's transact options.
+func (_Contract *ContractTransactorSession) AddIBCAppAutoPortId(app common.Address) (*types.Transaction, error) {
+	return _Contract.Contract.AddIBCAppAutoPortId(&_Contract.TransactOpts, app)
+}
@@ -0,0 +1,63 @@
+package ics26router
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverloads(t *testing.T) {
+	transactor, opts := newNoSendTransactor(t)
+	counterpartyInfo := DefaultCounterpartyInfo("client-0")
+	client := common.HexToAddress("0x03")
+
+	testCases := []struct {
+		name     string
+		send     func() (*types.Transaction, error)
+		selector string
+	}{
+		{
+			name: "add client with id",
+			send: func() (*types.Transaction, error) {
+				return transactor.AddClientWithId(opts, "custom-0", counterpartyInfo, client)
+			},
+			// addClient(string,(string,bytes[]),address)
+			selector: "0x1ec43e23",
+		},
+		{
+			name: "add client auto id",
+			send: func() (*types.Transaction, error) {
+				return transactor.AddClientAutoId(opts, counterpartyInfo, client)
+			},
+			// addClient((string,bytes[]),address)
+			selector: "0xe3cb36a0",
+		},
+		{
+			name: "add ibc app with port id",
+			send: func() (*types.Transaction, error) {
+				return transactor.AddIBCAppWithPortId(opts, "transfer", client)
+			},
+			// addIBCApp(string,address)
+			selector: "0x5f516889",
+		},
+		{
+			name: "add ibc app auto port id",
+			send: func() (*types.Transaction, error) {
+				return transactor.AddIBCAppAutoPortId(opts, client)
+			},
+			// addIBCApp(address)
+			selector: "0x4b720d5b",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx, err := tc.send()
+			require.NoError(t, err)
+			require.Equal(t, tc.selector, hexutil.Encode(tx.Data()[:4]))
+		})
+	}
+}
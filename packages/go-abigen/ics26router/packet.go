@@ -0,0 +1,68 @@
+package ics26router
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"slices"
+)
+
+// packetCommitmentVersion is the version prefix of IBC v2 packet commitments
+const packetCommitmentVersion = 2
+
+// PacketEqual reports whether a and b are the same packet, comparing every field including the payloads.
+func PacketEqual(a, b IICS26RouterMsgsPacket) bool {
+	return a.Sequence == b.Sequence &&
+		a.SourceClient == b.SourceClient &&
+		a.DestClient == b.DestClient &&
+		a.TimeoutTimestamp == b.TimeoutTimestamp &&
+		slices.EqualFunc(a.Payloads, b.Payloads, payloadEqual)
+}
+
+func payloadEqual(a, b IICS26RouterMsgsPayload) bool {
+	return a.SourcePort == b.SourcePort &&
+		a.DestPort == b.DestPort &&
+		a.Version == b.Version &&
+		a.Encoding == b.Encoding &&
+		bytes.Equal(a.Value, b.Value)
+}
+
+// PacketCommitment returns the commitment the router stores for a sent packet.
+// It mirrors ICS24Host.packetCommitmentBytes32:
+// sha256(0x02 || sha256(destClient) || sha256(bigEndian(timeout)) || sha256(hashPayload(p1) || ... || hashPayload(pn)))
+func PacketCommitment(p IICS26RouterMsgsPacket) [32]byte {
+	var appBytes []byte
+	for _, payload := range p.Payloads {
+		payloadHash := hashPayload(payload)
+		appBytes = append(appBytes, payloadHash[:]...)
+	}
+
+	destClientHash := sha256.Sum256([]byte(p.DestClient))
+	timeoutHash := sha256.Sum256(binary.BigEndian.AppendUint64(nil, p.TimeoutTimestamp))
+	appHash := sha256.Sum256(appBytes)
+
+	buf := make([]byte, 0, 1+3*sha256.Size)
+	buf = append(buf, packetCommitmentVersion)
+	buf = append(buf, destClientHash[:]...)
+	buf = append(buf, timeoutHash[:]...)
+	buf = append(buf, appHash[:]...)
+
+	return sha256.Sum256(buf)
+}
+
+// hashPayload mirrors ICS24Host.hashPayload
+func hashPayload(payload IICS26RouterMsgsPayload) [32]byte {
+	buf := make([]byte, 0, 5*sha256.Size)
+	for _, field := range [][]byte{
+		[]byte(payload.SourcePort),
+		[]byte(payload.DestPort),
+		[]byte(payload.Version),
+		[]byte(payload.Encoding),
+		payload.Value,
+	} {
+		fieldHash := sha256.Sum256(field)
+		buf = append(buf, fieldHash[:]...)
+	}
+
+	return sha256.Sum256(buf)
+}
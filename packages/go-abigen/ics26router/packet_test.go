@@ -0,0 +1,113 @@
+package ics26router
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/require"
+)
+
+// ics20PacketData mirrors IICS20TransferMsgs.FungibleTokenPacketData
+type ics20PacketData struct {
+	Denom    string
+	Sender   string
+	Receiver string
+	Amount   *big.Int
+	Memo     string
+}
+
+func encodeICS20PacketData(t *testing.T, data ics20PacketData) []byte {
+	t.Helper()
+
+	tupleType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "denom", Type: "string"},
+		{Name: "sender", Type: "string"},
+		{Name: "receiver", Type: "string"},
+		{Name: "amount", Type: "uint256"},
+		{Name: "memo", Type: "string"},
+	})
+	require.NoError(t, err)
+
+	bz, err := abi.Arguments{{Type: tupleType}}.Pack(data)
+	require.NoError(t, err)
+	return bz
+}
+
+func TestPacketCommitment(t *testing.T) {
+	// Same packet and expected value as ICS24HostTest.test_packetCommitment, which is in turn
+	// checked against the ibc-go implementation.
+	packet := IICS26RouterMsgsPacket{
+		Sequence:         1,
+		SourceClient:     "channel-0",
+		DestClient:       "channel-1",
+		TimeoutTimestamp: 100,
+		Payloads: []IICS26RouterMsgsPayload{{
+			SourcePort: "transfer",
+			DestPort:   "transfer",
+			Version:    "ics20-1",
+			Encoding:   "application/x-solidity-abi",
+			Value: encodeICS20PacketData(t, ics20PacketData{
+				Denom:    "uatom",
+				Sender:   "sender",
+				Receiver: "receiver",
+				Amount:   big.NewInt(1_000_000),
+				Memo:     "memo",
+			}),
+		}},
+	}
+
+	commitment := PacketCommitment(packet)
+	require.Equal(t, "b691a1950f6fb0bbbcf4bdb16fe2c4d0aa7ef783eb7803073f475cb8164d9b7a", hex.EncodeToString(commitment[:]))
+
+	// The commitment does not cover the sequence or source client
+	packet.Sequence = 2
+	packet.SourceClient = "channel-5"
+	require.Equal(t, commitment, PacketCommitment(packet))
+
+	packet.TimeoutTimestamp = 101
+	require.NotEqual(t, commitment, PacketCommitment(packet))
+}
+
+func TestPacketEqual(t *testing.T) {
+	newPacket := func() IICS26RouterMsgsPacket {
+		return IICS26RouterMsgsPacket{
+			Sequence:         1,
+			SourceClient:     "client-0",
+			DestClient:       "client-1",
+			TimeoutTimestamp: 100,
+			Payloads: []IICS26RouterMsgsPayload{{
+				SourcePort: "transfer",
+				DestPort:   "transfer",
+				Version:    "ics20-1",
+				Encoding:   "application/x-solidity-abi",
+				Value:      []byte{0x01, 0x02},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		malleate func(p *IICS26RouterMsgsPacket)
+		expEqual bool
+	}{
+		{"identical", func(*IICS26RouterMsgsPacket) {}, true},
+		{"sequence differs", func(p *IICS26RouterMsgsPacket) { p.Sequence = 2 }, false},
+		{"source client differs", func(p *IICS26RouterMsgsPacket) { p.SourceClient = "client-2" }, false},
+		{"dest client differs", func(p *IICS26RouterMsgsPacket) { p.DestClient = "client-2" }, false},
+		{"timeout differs", func(p *IICS26RouterMsgsPacket) { p.TimeoutTimestamp = 101 }, false},
+		{"payload value differs", func(p *IICS26RouterMsgsPacket) { p.Payloads[0].Value = []byte{0x01} }, false},
+		{"payload port differs", func(p *IICS26RouterMsgsPacket) { p.Payloads[0].DestPort = "gmpport" }, false},
+		{"extra payload", func(p *IICS26RouterMsgsPacket) { p.Payloads = append(p.Payloads, p.Payloads[0]) }, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b := newPacket(), newPacket()
+			tc.malleate(&b)
+			require.Equal(t, tc.expEqual, PacketEqual(a, b))
+			require.Equal(t, tc.expEqual, PacketEqual(b, a))
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package ics26router
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// SP1Proof mirrors ISP1Msgs.SP1Proof, the proof of the SP1 program verifying a tendermint header.
+type SP1Proof struct {
+	VKey         [32]byte
+	PublicValues []byte
+	Proof        []byte
+}
+
+// StateAttestation mirrors IAttestationMsgs.StateAttestation, the state signed by the attestors.
+type StateAttestation struct {
+	Height    uint64
+	Timestamp uint64
+}
+
+// attestationProof mirrors IAttestationMsgs.AttestationProof
+type attestationProof struct {
+	AttestationData []byte
+	Signatures      [][]byte
+}
+
+// msgUpdateClient mirrors IUpdateClientMsgs.MsgUpdateClient of the SP1ICS07Tendermint client
+type msgUpdateClient struct {
+	Sp1Proof SP1Proof
+}
+
+var (
+	sp1ProofComponents = []abi.ArgumentMarshaling{
+		{Name: "vKey", Type: "bytes32"},
+		{Name: "publicValues", Type: "bytes"},
+		{Name: "proof", Type: "bytes"},
+	}
+
+	msgUpdateClientArgs = tupleArguments([]abi.ArgumentMarshaling{
+		{Name: "sp1Proof", Type: "tuple", Components: sp1ProofComponents},
+	})
+	stateAttestationArgs = tupleArguments([]abi.ArgumentMarshaling{
+		{Name: "height", Type: "uint64"},
+		{Name: "timestamp", Type: "uint64"},
+	})
+	attestationProofArgs = tupleArguments([]abi.ArgumentMarshaling{
+		{Name: "attestationData", Type: "bytes"},
+		{Name: "signatures", Type: "bytes[]"},
+	})
+)
+
+// tupleArguments returns the arguments of abi.encode for a single struct with the given fields
+func tupleArguments(components []abi.ArgumentMarshaling) abi.Arguments {
+	tupleType, err := abi.NewType("tuple", "", components)
+	if err != nil {
+		panic(err)
+	}
+	return abi.Arguments{{Type: tupleType}}
+}
+
+// EncodeSP1ICS07UpdateMsg returns the updateMsg bytes of UpdateClient for an SP1ICS07Tendermint client, which
+// decodes them as IUpdateClientMsgs.MsgUpdateClient. The tendermint header itself is not part of the message,
+// it is verified by the update client program whose proof is given.
+func EncodeSP1ICS07UpdateMsg(proof SP1Proof) ([]byte, error) {
+	return msgUpdateClientArgs.Pack(msgUpdateClient{Sp1Proof: proof})
+}
+
+// DecodeSP1ICS07UpdateMsg decodes the updateMsg bytes of an SP1ICS07Tendermint client.
+func DecodeSP1ICS07UpdateMsg(updateMsg []byte) (SP1Proof, error) {
+	var msg msgUpdateClient
+	if err := unpackTuple(msgUpdateClientArgs, updateMsg, &msg); err != nil {
+		return SP1Proof{}, fmt.Errorf("decoding MsgUpdateClient: %w", err)
+	}
+	return msg.Sp1Proof, nil
+}
+
+// EncodeStateAttestation returns the attestationData of a client update, the bytes the attestors sign.
+func EncodeStateAttestation(state StateAttestation) ([]byte, error) {
+	return stateAttestationArgs.Pack(state)
+}
+
+// EncodeAttestationUpdateMsg returns the updateMsg bytes of UpdateClient for an AttestationLightClient, which
+// decodes them as IAttestationMsgs.AttestationProof over the encoded state. Each signature is the 65-byte
+// (r||s||v) signature of an attestor.
+func EncodeAttestationUpdateMsg(state StateAttestation, signatures [][]byte) ([]byte, error) {
+	data, err := EncodeStateAttestation(state)
+	if err != nil {
+		return nil, err
+	}
+	return attestationProofArgs.Pack(attestationProof{AttestationData: data, Signatures: signatures})
+}
+
+// DecodeAttestationUpdateMsg decodes the updateMsg bytes of an AttestationLightClient.
+func DecodeAttestationUpdateMsg(updateMsg []byte) (StateAttestation, [][]byte, error) {
+	var proof attestationProof
+	if err := unpackTuple(attestationProofArgs, updateMsg, &proof); err != nil {
+		return StateAttestation{}, nil, fmt.Errorf("decoding AttestationProof: %w", err)
+	}
+
+	var state StateAttestation
+	if err := unpackTuple(stateAttestationArgs, proof.AttestationData, &state); err != nil {
+		return StateAttestation{}, nil, fmt.Errorf("decoding StateAttestation: %w", err)
+	}
+	return state, proof.Signatures, nil
+}
+
+// unpackTuple decodes the abi.encode of a single struct into out
+func unpackTuple(args abi.Arguments, data []byte, out any) error {
+	values, err := args.Unpack(data)
+	if err != nil {
+		return err
+	}
+	abi.ConvertType(values[0], out)
+	return nil
+}
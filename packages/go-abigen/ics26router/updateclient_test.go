@@ -0,0 +1,55 @@
+package ics26router
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSP1ICS07UpdateMsg(t *testing.T) {
+	proof := SP1Proof{
+		VKey:         common.HexToHash("0x00a1b2c3"),
+		PublicValues: []byte("public values"),
+		Proof:        bytes.Repeat([]byte{0xab}, 40),
+	}
+
+	updateMsg, err := EncodeSP1ICS07UpdateMsg(proof)
+	require.NoError(t, err)
+
+	// abi.encode(MsgUpdateClient) starts with the offsets of the outer and the nested dynamic tuple
+	require.Equal(t, common.BigToHash(common.Big32).Bytes(), updateMsg[:32])
+	require.Equal(t, common.BigToHash(common.Big32).Bytes(), updateMsg[32:64])
+	require.Equal(t, proof.VKey[:], updateMsg[64:96])
+
+	decoded, err := DecodeSP1ICS07UpdateMsg(updateMsg)
+	require.NoError(t, err)
+	require.Equal(t, proof, decoded)
+
+	_, err = DecodeSP1ICS07UpdateMsg([]byte{0x01})
+	require.ErrorContains(t, err, "decoding MsgUpdateClient")
+}
+
+func TestAttestationUpdateMsg(t *testing.T) {
+	state := StateAttestation{Height: 42, Timestamp: 1_700_000_000}
+	signatures := [][]byte{bytes.Repeat([]byte{0x01}, 65), bytes.Repeat([]byte{0x02}, 65)}
+
+	data, err := EncodeStateAttestation(state)
+	require.NoError(t, err)
+	// Both fields are static, so the attested data is the two words themselves
+	require.Equal(t, append(common.BigToHash(big.NewInt(42)).Bytes(), common.BigToHash(big.NewInt(1_700_000_000)).Bytes()...), data)
+
+	updateMsg, err := EncodeAttestationUpdateMsg(state, signatures)
+	require.NoError(t, err)
+	require.True(t, bytes.Contains(updateMsg, data))
+
+	decodedState, decodedSignatures, err := DecodeAttestationUpdateMsg(updateMsg)
+	require.NoError(t, err)
+	require.Equal(t, state, decodedState)
+	require.Equal(t, signatures, decodedSignatures)
+
+	_, _, err = DecodeAttestationUpdateMsg([]byte{0x01})
+	require.ErrorContains(t, err, "decoding AttestationProof")
+}
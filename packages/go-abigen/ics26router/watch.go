@@ -0,0 +1,165 @@
+package ics26router
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+var (
+	// watchAllSettle is how long WatchAll holds events waiting for more, since each event type is
+	// delivered by its own subscription and the logs of one block may arrive on them in any order.
+	watchAllSettle = 100 * time.Millisecond
+	// watchAllMaxHold bounds how long WatchAll holds an event when new ones keep resetting the settle interval.
+	watchAllMaxHold = 2 * time.Second
+	// watchAllResubscribeBackoff is the maximum wait between attempts to re-establish a failed subscription.
+	watchAllResubscribeBackoff = 30 * time.Second
+)
+
+// PacketEvent holds exactly one of the packet lifecycle events delivered by WatchAll.
+type PacketEvent struct {
+	SendPacket           *ContractSendPacket
+	WriteAcknowledgement *ContractWriteAcknowledgement
+	TimeoutPacket        *ContractTimeoutPacket
+}
+
+// Raw returns the log the event was decoded from.
+func (e PacketEvent) Raw() types.Log {
+	switch {
+	case e.SendPacket != nil:
+		return e.SendPacket.Raw
+	case e.WriteAcknowledgement != nil:
+		return e.WriteAcknowledgement.Raw
+	case e.TimeoutPacket != nil:
+		return e.TimeoutPacket.Raw
+	default:
+		return types.Log{}
+	}
+}
+
+// WatchAll subscribes to the SendPacket, WriteAcknowledgement and TimeoutPacket events of the given
+// clients and delivers them on sink, ordered by block number and log index. Events are held until
+// none has arrived for a short settle interval, so that the logs of a block are sorted together, but
+// never longer than a maximum hold time so that a steady stream of events is still delivered.
+// Failed subscriptions are re-established with backoff; opts.Start only applies to the first attempt.
+// When opts.Context is done, the subscription ends with its error.
+func (_Contract *ContractFilterer) WatchAll(opts *bind.WatchOpts, sink chan<- PacketEvent, clientIds []string) (event.Subscription, error) {
+	if opts == nil {
+		opts = new(bind.WatchOpts)
+	}
+
+	sends := make(chan *ContractSendPacket)
+	acks := make(chan *ContractWriteAcknowledgement)
+	timeouts := make(chan *ContractTimeoutPacket)
+
+	subs := []event.Subscription{
+		resubscribe(opts, func(opts *bind.WatchOpts) (event.Subscription, error) {
+			return _Contract.WatchSendPacket(opts, sends, clientIds, nil)
+		}),
+		resubscribe(opts, func(opts *bind.WatchOpts) (event.Subscription, error) {
+			return _Contract.WatchWriteAcknowledgement(opts, acks, clientIds, nil)
+		}),
+		resubscribe(opts, func(opts *bind.WatchOpts) (event.Subscription, error) {
+			return _Contract.WatchTimeoutPacket(opts, timeouts, clientIds, nil)
+		}),
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer func() {
+			for _, sub := range subs {
+				sub.Unsubscribe()
+			}
+		}()
+
+		settle := time.NewTimer(watchAllSettle)
+		settle.Stop()
+		defer settle.Stop()
+		maxHold := time.NewTimer(watchAllMaxHold)
+		maxHold.Stop()
+		defer maxHold.Stop()
+
+		var done <-chan struct{}
+		if opts.Context != nil {
+			done = opts.Context.Done()
+		}
+
+		var pending []PacketEvent
+		hold := func(ev PacketEvent) {
+			if len(pending) == 0 {
+				maxHold.Reset(watchAllMaxHold)
+			}
+			pending = append(pending, ev)
+			settle.Reset(watchAllSettle)
+		}
+		// flush delivers the pending events and reports whether the subscription was closed meanwhile
+		flush := func() bool {
+			settle.Stop()
+			maxHold.Stop()
+			sortPacketEvents(pending)
+			for _, ev := range pending {
+				select {
+				case sink <- ev:
+				case <-quit:
+					return false
+				}
+			}
+			pending = nil
+			return true
+		}
+
+		for {
+			select {
+			case ev := <-sends:
+				hold(PacketEvent{SendPacket: ev})
+			case ev := <-acks:
+				hold(PacketEvent{WriteAcknowledgement: ev})
+			case ev := <-timeouts:
+				hold(PacketEvent{TimeoutPacket: ev})
+			case <-settle.C:
+				if !flush() {
+					return nil
+				}
+			case <-maxHold.C:
+				if !flush() {
+					return nil
+				}
+			case <-done:
+				return opts.Context.Err()
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// resubscribe keeps the subscription returned by watch established, clearing opts.Start after the
+// first attempt so that a re-established subscription does not replay history. Each attempt is
+// cancelled with opts.Context.
+func resubscribe(opts *bind.WatchOpts, watch func(*bind.WatchOpts) (event.Subscription, error)) event.Subscription {
+	start := opts.Start
+	return event.Resubscribe(watchAllResubscribeBackoff, func(ctx context.Context) (event.Subscription, error) {
+		if opts.Context != nil {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(ctx)
+			stop := context.AfterFunc(opts.Context, cancel)
+			context.AfterFunc(ctx, func() { stop() })
+		}
+		attemptOpts := &bind.WatchOpts{Start: start, Context: ctx}
+		start = nil
+		return watch(attemptOpts)
+	})
+}
+
+func sortPacketEvents(events []PacketEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i].Raw(), events[j].Raw()
+		if a.BlockNumber != b.BlockNumber {
+			return a.BlockNumber < b.BlockNumber
+		}
+		return a.Index < b.Index
+	})
+}
@@ -0,0 +1,220 @@
+package ics26router
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// logSubscription is an ethereum.Subscription whose failure is triggered by the test
+type logSubscription struct {
+	err  chan error
+	once sync.Once
+}
+
+func (s *logSubscription) Unsubscribe() {
+	s.once.Do(func() { close(s.err) })
+}
+
+func (s *logSubscription) Err() <-chan error {
+	return s.err
+}
+
+// logBackend is a bind.ContractFilterer that lets the test push logs to the live subscription of each event
+type logBackend struct {
+	mu    sync.Mutex
+	subs  map[common.Hash]chan<- types.Log
+	fails map[common.Hash]*logSubscription
+	count map[common.Hash]int
+}
+
+func newLogBackend() *logBackend {
+	return &logBackend{
+		subs:  make(map[common.Hash]chan<- types.Log),
+		fails: make(map[common.Hash]*logSubscription),
+		count: make(map[common.Hash]int),
+	}
+}
+
+func (b *logBackend) FilterLogs(context.Context, ethereum.FilterQuery) ([]types.Log, error) {
+	return nil, nil
+}
+
+func (b *logBackend) SubscribeFilterLogs(_ context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	topic := query.Topics[0][0]
+	sub := &logSubscription{err: make(chan error, 1)}
+	b.subs[topic] = ch
+	b.fails[topic] = sub
+	b.count[topic]++
+	return sub, nil
+}
+
+func (b *logBackend) subscriptions(topic common.Hash) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.count[topic]
+}
+
+func (b *logBackend) emit(t *testing.T, log types.Log) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		_, ok := b.subs[log.Topics[0]]
+		return ok
+	}, time.Second, time.Millisecond)
+
+	b.mu.Lock()
+	ch := b.subs[log.Topics[0]]
+	b.mu.Unlock()
+	ch <- log
+}
+
+func (b *logBackend) fail(topic common.Hash) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fails[topic].err <- errors.New("connection lost")
+	delete(b.subs, topic)
+}
+
+func packetLog(t *testing.T, parsed *abi.ABI, name string, block uint64, index uint, data ...any) types.Log {
+	t.Helper()
+
+	ev := parsed.Events[name]
+	packed, err := ev.Inputs.NonIndexed().Pack(data...)
+	require.NoError(t, err)
+
+	return types.Log{
+		Topics: []common.Hash{
+			ev.ID,
+			crypto.Keccak256Hash([]byte("client-0")),
+			common.BigToHash(big.NewInt(1)),
+		},
+		Data:        packed,
+		BlockNumber: block,
+		Index:       index,
+	}
+}
+
+func TestWatchAll(t *testing.T) {
+	settle, backoff := watchAllSettle, watchAllResubscribeBackoff
+	t.Cleanup(func() { watchAllSettle, watchAllResubscribeBackoff = settle, backoff })
+	watchAllSettle = 20 * time.Millisecond
+	watchAllResubscribeBackoff = 10 * time.Millisecond
+
+	parsed, err := ContractMetaData.GetAbi()
+	require.NoError(t, err)
+
+	backend := newLogBackend()
+	filterer, err := NewContractFilterer(common.HexToAddress("0x01"), backend)
+	require.NoError(t, err)
+
+	sink := make(chan PacketEvent)
+	sub, err := filterer.WatchAll(nil, sink, []string{"client-0"})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	packet := validRecvPacketMsg(time.Unix(1_700_000_000, 0)).Packet
+
+	// Pushed out of order across the three subscriptions
+	backend.emit(t, packetLog(t, parsed, "TimeoutPacket", 2, 0, packet))
+	backend.emit(t, packetLog(t, parsed, "WriteAcknowledgement", 1, 3, packet, [][]byte{[]byte("ack")}))
+	backend.emit(t, packetLog(t, parsed, "SendPacket", 1, 1, packet))
+
+	var received []PacketEvent
+	for range 3 {
+		select {
+		case ev := <-sink:
+			received = append(received, ev)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for events")
+		}
+	}
+
+	require.NotNil(t, received[0].SendPacket)
+	require.True(t, PacketEqual(packet, received[0].SendPacket.Packet))
+	require.NotNil(t, received[1].WriteAcknowledgement)
+	require.Equal(t, [][]byte{[]byte("ack")}, received[1].WriteAcknowledgement.Acknowledgements)
+	require.NotNil(t, received[2].TimeoutPacket)
+	require.Equal(t, uint64(2), received[2].Raw().BlockNumber)
+
+	// A failed subscription is re-established and keeps delivering
+	sendID := parsed.Events["SendPacket"].ID
+	backend.fail(sendID)
+	backend.emit(t, packetLog(t, parsed, "SendPacket", 3, 0, packet))
+
+	select {
+	case ev := <-sink:
+		require.NotNil(t, ev.SendPacket)
+		require.Equal(t, uint64(3), ev.Raw().BlockNumber)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the resubscribed event")
+	}
+	require.Equal(t, 2, backend.subscriptions(sendID))
+}
+
+func TestWatchAllMaxHold(t *testing.T) {
+	settle, maxHold := watchAllSettle, watchAllMaxHold
+	t.Cleanup(func() { watchAllSettle, watchAllMaxHold = settle, maxHold })
+	watchAllSettle = 50 * time.Millisecond
+	watchAllMaxHold = 200 * time.Millisecond
+
+	parsed, err := ContractMetaData.GetAbi()
+	require.NoError(t, err)
+
+	backend := newLogBackend()
+	filterer, err := NewContractFilterer(common.HexToAddress("0x01"), backend)
+	require.NoError(t, err)
+
+	sink := make(chan PacketEvent, 100)
+	sub, err := filterer.WatchAll(nil, sink, []string{"client-0"})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	packet := validRecvPacketMsg(time.Unix(1_700_000_000, 0)).Packet
+
+	// Events arriving faster than the settle interval are still flushed once the maximum hold time is reached
+	start := time.Now()
+	for block := uint64(1); len(sink) == 0; block++ {
+		require.Less(t, time.Since(start), time.Second, "events were held past the maximum hold time")
+		backend.emit(t, packetLog(t, parsed, "SendPacket", block, 0, packet))
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ev := <-sink
+	require.Equal(t, uint64(1), ev.Raw().BlockNumber)
+}
+
+func TestWatchAllContext(t *testing.T) {
+	backend := newLogBackend()
+	filterer, err := NewContractFilterer(common.HexToAddress("0x01"), backend)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := filterer.WatchAll(&bind.WatchOpts{Context: ctx}, make(chan PacketEvent), []string{"client-0"})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	cancel()
+	select {
+	case err := <-sub.Err():
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("subscription did not end with the caller's context")
+	}
+}
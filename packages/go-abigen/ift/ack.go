@@ -0,0 +1,65 @@
+package ift
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// UniversalErrorAck is the acknowledgement written by ICS26 when the destination app fails.
+// It mirrors ICS24Host.UNIVERSAL_ERROR_ACK.
+var UniversalErrorAck = func() []byte {
+	hash := sha256.Sum256([]byte("UNIVERSAL_ERROR_ACKNOWLEDGEMENT"))
+	return hash[:]
+}()
+
+var (
+	// ErrEmptyAcknowledgement is returned for an acknowledgement with no bytes
+	ErrEmptyAcknowledgement = errors.New("empty acknowledgement")
+	// ErrUniversalErrorAck is returned for the universal error acknowledgement. It carries no reason,
+	// the counterparty only signals that the packet failed.
+	ErrUniversalErrorAck = errors.New("counterparty returned the universal error acknowledgement")
+	// ErrErrorAcknowledgement is returned for an error acknowledgement that carries a reason
+	ErrErrorAcknowledgement = errors.New("counterparty returned an error acknowledgement")
+)
+
+// jsonAcknowledgement is the JSON envelope of an ibc-go acknowledgement
+type jsonAcknowledgement struct {
+	Result []byte  `json:"result,omitempty"`
+	Error  *string `json:"error,omitempty"`
+}
+
+// AcknowledgementError decodes an acknowledgement and returns nil if it is a success acknowledgement.
+// Otherwise the returned error wraps ErrUniversalErrorAck, ErrErrorAcknowledgement or ErrEmptyAcknowledgement,
+// and its message is the human-readable reason.
+//
+// The IFT contract only refunds on the universal error acknowledgement, which ICS26 writes for every failed
+// receive, so the reason of a refunded transfer is usually not available on this side.
+func AcknowledgementError(ack []byte) error {
+	if len(ack) == 0 {
+		return ErrEmptyAcknowledgement
+	}
+
+	if bytes.Equal(ack, UniversalErrorAck) {
+		return ErrUniversalErrorAck
+	}
+
+	var envelope jsonAcknowledgement
+	if err := json.Unmarshal(ack, &envelope); err == nil && envelope.Error != nil {
+		return fmt.Errorf("%w: %s", ErrErrorAcknowledgement, *envelope.Error)
+	}
+
+	return nil
+}
+
+// RefundReason returns ErrUniversalErrorAck if the IFT contract refunds the transfer of the given callback, or nil
+// otherwise. The contract only refunds on the universal error acknowledgement, so any other acknowledgement, including
+// a JSON error envelope, yields nil; use AcknowledgementError to decode those.
+func RefundReason(msg IIBCAppCallbacksOnAcknowledgementPacketCallback) error {
+	if bytes.Equal(msg.Acknowledgement, UniversalErrorAck) {
+		return ErrUniversalErrorAck
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package ift
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniversalErrorAck(t *testing.T) {
+	// ICS24Host.UNIVERSAL_ERROR_ACK
+	require.Equal(t, "4774d4a575993f963b1c06573736617a457abef8589178db8d10c94b4ab511ab", hex.EncodeToString(UniversalErrorAck))
+}
+
+func TestAcknowledgementError(t *testing.T) {
+	gmpAckType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{{Name: "result", Type: "bytes"}})
+	require.NoError(t, err)
+	gmpAck, err := abi.Arguments{{Type: gmpAckType}}.Pack(struct{ Result []byte }{Result: []byte{0x01}})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		ack         []byte
+		expectedErr error
+		expectedMsg string
+	}{
+		{
+			name: "success: GMP acknowledgement",
+			ack:  gmpAck,
+		},
+		{
+			name: "success: json result envelope",
+			ack:  []byte(`{"result":"AQ=="}`),
+		},
+		{
+			name:        "failure: universal error acknowledgement",
+			ack:         UniversalErrorAck,
+			expectedErr: ErrUniversalErrorAck,
+			expectedMsg: "counterparty returned the universal error acknowledgement",
+		},
+		{
+			name:        "failure: json error envelope",
+			ack:         []byte(`{"error":"ABCI code: 5: error handling packet: insufficient funds"}`),
+			expectedErr: ErrErrorAcknowledgement,
+			expectedMsg: "counterparty returned an error acknowledgement: ABCI code: 5: error handling packet: insufficient funds",
+		},
+		{
+			name:        "failure: empty acknowledgement",
+			ack:         nil,
+			expectedErr: ErrEmptyAcknowledgement,
+			expectedMsg: "empty acknowledgement",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := AcknowledgementError(tc.ack)
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.EqualError(t, err, tc.expectedMsg)
+		})
+	}
+}
+
+func TestRefundReason(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ack         []byte
+		expectedErr error
+	}{
+		{
+			name:        "refund: universal error acknowledgement",
+			ack:         UniversalErrorAck,
+			expectedErr: ErrUniversalErrorAck,
+		},
+		{
+			name: "no refund: json error envelope",
+			ack:  []byte(`{"error":"ABCI code: 5: error handling packet: insufficient funds"}`),
+		},
+		{
+			name: "no refund: json result envelope",
+			ack:  []byte(`{"result":"AQ=="}`),
+		},
+		{
+			name: "no refund: empty acknowledgement",
+			ack:  nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := RefundReason(IIBCAppCallbacksOnAcknowledgementPacketCallback{Acknowledgement: tc.ack})
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package ift
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// ERC20Metadata holds the display metadata of the IFT token.
+type ERC20Metadata struct {
+	Name     string
+	Symbol   string
+	Decimals uint8
+}
+
+// Metadata fetches the token name, symbol and decimals. The contract does not inherit
+// Multicall, so the three views are queried sequentially against the same opts.
+func (_Contract *ContractCaller) Metadata(opts *bind.CallOpts) (ERC20Metadata, error) {
+	name, err := _Contract.Name(opts)
+	if err != nil {
+		return ERC20Metadata{}, fmt.Errorf("failed to fetch name: %w", err)
+	}
+
+	symbol, err := _Contract.Symbol(opts)
+	if err != nil {
+		return ERC20Metadata{}, fmt.Errorf("failed to fetch symbol: %w", err)
+	}
+
+	decimals, err := _Contract.Decimals(opts)
+	if err != nil {
+		return ERC20Metadata{}, fmt.Errorf("failed to fetch decimals: %w", err)
+	}
+
+	return ERC20Metadata{
+		Name:     name,
+		Symbol:   symbol,
+		Decimals: decimals,
+	}, nil
+}
+
+// Metadata fetches the token name, symbol and decimals using the session's call options.
+func (_Contract *ContractCallerSession) Metadata() (ERC20Metadata, error) {
+	return _Contract.Contract.Metadata(&_Contract.CallOpts)
+}
+
+// Metadata fetches the token name, symbol and decimals using the session's call options.
+func (_Contract *ContractSession) Metadata() (ERC20Metadata, error) {
+	return _Contract.Contract.Metadata(&_Contract.CallOpts)
+}
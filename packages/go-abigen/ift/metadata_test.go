@@ -0,0 +1,76 @@
+package ift
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// erc20Backend is a bind.ContractCaller that answers ERC20 metadata views from fixed values
+type erc20Backend struct {
+	t       *testing.T
+	abi     *abi.ABI
+	results map[string][]any
+	calls   []string
+}
+
+func newERC20Backend(t *testing.T, results map[string][]any) *erc20Backend {
+	t.Helper()
+
+	parsed, err := ContractMetaData.GetAbi()
+	require.NoError(t, err)
+
+	return &erc20Backend{t: t, abi: parsed, results: results}
+}
+
+func (b *erc20Backend) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (b *erc20Backend) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	method, err := b.abi.MethodById(call.Data)
+	require.NoError(b.t, err)
+	b.calls = append(b.calls, method.Name)
+
+	result, ok := b.results[method.Name]
+	if !ok {
+		return nil, errors.New("execution reverted")
+	}
+	return method.Outputs.Pack(result...)
+}
+
+func TestMetadata(t *testing.T) {
+	backend := newERC20Backend(t, map[string][]any{
+		"name":     {"Interchain Token"},
+		"symbol":   {"IFT"},
+		"decimals": {uint8(18)},
+	})
+
+	caller, err := NewContractCaller(common.HexToAddress("0x01"), backend)
+	require.NoError(t, err)
+
+	metadata, err := caller.Metadata(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, ERC20Metadata{Name: "Interchain Token", Symbol: "IFT", Decimals: 18}, metadata)
+	require.Equal(t, []string{"name", "symbol", "decimals"}, backend.calls)
+}
+
+func TestMetadataError(t *testing.T) {
+	backend := newERC20Backend(t, map[string][]any{
+		"name": {"Interchain Token"},
+	})
+
+	caller, err := NewContractCaller(common.HexToAddress("0x01"), backend)
+	require.NoError(t, err)
+
+	_, err = caller.Metadata(&bind.CallOpts{})
+	require.ErrorContains(t, err, "failed to fetch symbol")
+	require.Equal(t, []string{"name", "symbol"}, backend.calls)
+}
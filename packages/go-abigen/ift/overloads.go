@@ -0,0 +1,39 @@
+package ift
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// IftTransferWithTimeout sends the iftTransfer overload with an explicit timeout timestamp, in unix seconds (IftTransfer).
+func (_Contract *ContractTransactor) IftTransferWithTimeout(opts *bind.TransactOpts, clientId string, receiver string, amount *big.Int, timeoutTimestamp uint64) (*types.Transaction, error) {
+	return _Contract.IftTransfer(opts, clientId, receiver, amount, timeoutTimestamp)
+}
+
+// IftTransferWithTimeout sends the iftTransfer overload with an explicit timeout timestamp using the session's transact options.
+func (_Contract *ContractSession) IftTransferWithTimeout(clientId string, receiver string, amount *big.Int, timeoutTimestamp uint64) (*types.Transaction, error) {
+	return _Contract.Contract.IftTransferWithTimeout(&_Contract.TransactOpts, clientId, receiver, amount, timeoutTimestamp)
+}
+
+// IftTransferWithTimeout sends the iftTransfer overload with an explicit timeout timestamp using the session's transact options.
+func (_Contract *ContractTransactorSession) IftTransferWithTimeout(clientId string, receiver string, amount *big.Int, timeoutTimestamp uint64) (*types.Transaction, error) {
+	return _Contract.Contract.IftTransferWithTimeout(&_Contract.TransactOpts, clientId, receiver, amount, timeoutTimestamp)
+}
+
+// IftTransferDefaultTimeout sends the iftTransfer overload timing out after the contract's DefaultTimeoutWindow,
+// counted from the block timestamp (IftTransfer0).
+func (_Contract *ContractTransactor) IftTransferDefaultTimeout(opts *bind.TransactOpts, clientId string, receiver string, amount *big.Int) (*types.Transaction, error) {
+	return _Contract.IftTransfer0(opts, clientId, receiver, amount)
+}
+
+// IftTransferDefaultTimeout sends the iftTransfer overload with the contract's default timeout using the session's transact options.
+func (_Contract *ContractSession) IftTransferDefaultTimeout(clientId string, receiver string, amount *big.Int) (*types.Transaction, error) {
+	return _Contract.Contract.IftTransferDefaultTimeout(&_Contract.TransactOpts, clientId, receiver, amount)
+}
+
+// IftTransferDefaultTimeout sends the iftTransfer overload with the contract's default timeout using the session's transact options.
+func (_Contract *ContractTransactorSession) IftTransferDefaultTimeout(clientId string, receiver string, amount *big.Int) (*types.Transaction, error) {
+	return _Contract.Contract.IftTransferDefaultTimeout(&_Contract.TransactOpts, clientId, receiver, amount)
+}
@@ -0,0 +1,45 @@
+package ift
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIftTransferOverloads(t *testing.T) {
+	transactor, opts := newNoSendTransactor(t)
+
+	testCases := []struct {
+		name     string
+		send     func() (*types.Transaction, error)
+		selector string
+	}{
+		{
+			name: "with timeout",
+			send: func() (*types.Transaction, error) {
+				return transactor.IftTransferWithTimeout(opts, "client-0", "cosmos1receiver", big.NewInt(100), 1_700_000_000)
+			},
+			// iftTransfer(string,string,uint256,uint64)
+			selector: "0x711708b3",
+		},
+		{
+			name: "default timeout",
+			send: func() (*types.Transaction, error) {
+				return transactor.IftTransferDefaultTimeout(opts, "client-0", "cosmos1receiver", big.NewInt(100))
+			},
+			// iftTransfer(string,string,uint256)
+			selector: "0xd88a36fe",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx, err := tc.send()
+			require.NoError(t, err)
+			require.Equal(t, tc.selector, hexutil.Encode(tx.Data()[:4]))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package ift
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrInvalidConstructorInterface mirrors the IFTInvalidConstructorInterface revert of registerIFTBridge.
+var ErrInvalidConstructorInterface = errors.New("send call constructor does not implement IIFTSendCallConstructor")
+
+var (
+	// SendCallConstructorInterfaceID is type(IIFTSendCallConstructor).interfaceId, the XOR of the selectors
+	// the interface declares, of which there is only constructMintCall.
+	SendCallConstructorInterfaceID = selector("constructMintCall(string,uint256)")
+
+	// erc165InterfaceID is type(IERC165).interfaceId
+	erc165InterfaceID = selector("supportsInterface(bytes4)")
+	// invalidInterfaceID must not be supported by any ERC165 contract
+	invalidInterfaceID = [4]byte{0xff, 0xff, 0xff, 0xff}
+)
+
+func selector(signature string) [4]byte {
+	return [4]byte(crypto.Keccak256([]byte(signature))[:4])
+}
+
+// CheckSendCallConstructor performs the ERC165Checker.supportsInterface check registerIFTBridge runs on its
+// iftSendCallConstructor argument, so that an unsupported constructor is reported before sending the transaction.
+// Like ERC165Checker, a supportsInterface call that reverts counts as the interface not being supported; only
+// errors reaching the node are returned as is.
+func CheckSendCallConstructor(opts *bind.CallOpts, backend bind.ContractCaller, constructor common.Address) error {
+	// The IFT binding is only used for supportsInterface, which has the same ABI on any ERC165 contract
+	caller, err := NewContractCaller(constructor, backend)
+	if err != nil {
+		return err
+	}
+
+	for _, check := range []struct {
+		interfaceID [4]byte
+		expected    bool
+	}{
+		{interfaceID: erc165InterfaceID, expected: true},
+		{interfaceID: invalidInterfaceID, expected: false},
+		{interfaceID: SendCallConstructorInterfaceID, expected: true},
+	} {
+		supported, err := caller.SupportsInterface(opts, check.interfaceID)
+		if errors.Is(err, bind.ErrNoCode) {
+			return fmt.Errorf("%w: %s has no code", ErrInvalidConstructorInterface, constructor)
+		}
+		if err != nil && isExecutionRevert(err) {
+			return fmt.Errorf("%w: %s reverted on supportsInterface(%#x): %w", ErrInvalidConstructorInterface, constructor, check.interfaceID, err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to call supportsInterface(%#x) on %s: %w", check.interfaceID, constructor, err)
+		}
+		if supported != check.expected {
+			return fmt.Errorf("%w: %s", ErrInvalidConstructorInterface, constructor)
+		}
+	}
+
+	return nil
+}
+
+// isExecutionRevert reports whether err is the node reporting that the call reverted, either with revert data
+// or, for a revert without reason, only through its message.
+func isExecutionRevert(err error) bool {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) && dataErr.ErrorData() != nil {
+		return true
+	}
+	return strings.Contains(err.Error(), "execution reverted")
+}
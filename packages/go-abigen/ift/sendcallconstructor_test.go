@@ -0,0 +1,158 @@
+package ift
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// erc165Backend is a bind.ContractCaller answering supportsInterface for a fixed set of interface IDs
+type erc165Backend struct {
+	t         *testing.T
+	abi       *abi.ABI
+	code      []byte
+	supported map[[4]byte]bool
+	// callErr, when set, is returned by every call instead of the supportsInterface result
+	callErr error
+}
+
+// revertError is a JSON-RPC error carrying revert data, as returned by eth_call for a reverting call
+type revertError struct{}
+
+func (revertError) Error() string  { return "execution reverted: unsupported" }
+func (revertError) ErrorCode() int { return 3 }
+func (revertError) ErrorData() any { return "0x08c379a0" }
+
+func newERC165Backend(t *testing.T, supported ...[4]byte) *erc165Backend {
+	t.Helper()
+
+	parsed, err := ContractMetaData.GetAbi()
+	require.NoError(t, err)
+
+	backend := &erc165Backend{t: t, abi: parsed, code: []byte{0x01}, supported: make(map[[4]byte]bool)}
+	for _, interfaceID := range supported {
+		backend.supported[interfaceID] = true
+	}
+	return backend
+}
+
+func (b *erc165Backend) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return b.code, nil
+}
+
+func (b *erc165Backend) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	if len(b.code) == 0 {
+		return nil, nil
+	}
+	if b.callErr != nil {
+		return nil, b.callErr
+	}
+
+	method, err := b.abi.MethodById(call.Data)
+	require.NoError(b.t, err)
+	require.Equal(b.t, "supportsInterface", method.Name)
+
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	require.NoError(b.t, err)
+	return method.Outputs.Pack(b.supported[args[0].([4]byte)])
+}
+
+func TestSendCallConstructorInterfaceID(t *testing.T) {
+	// type(IIFTSendCallConstructor).interfaceId, as embedded in the IFT bytecode
+	require.Equal(t, [4]byte{0x56, 0xd9, 0x81, 0xa7}, SendCallConstructorInterfaceID)
+	require.Contains(t, ContractMetaData.Bin, "7f56d981a7")
+}
+
+func TestCheckSendCallConstructor(t *testing.T) {
+	constructor := common.HexToAddress("0x03")
+
+	testCases := []struct {
+		name    string
+		backend func(t *testing.T) *erc165Backend
+		expErr  error
+	}{
+		{
+			name: "success",
+			backend: func(t *testing.T) *erc165Backend {
+				return newERC165Backend(t, erc165InterfaceID, SendCallConstructorInterfaceID)
+			},
+		},
+		{
+			name: "failure: ERC165 contract without the interface",
+			backend: func(t *testing.T) *erc165Backend {
+				return newERC165Backend(t, erc165InterfaceID)
+			},
+			expErr: ErrInvalidConstructorInterface,
+		},
+		{
+			name: "failure: interface claimed without ERC165",
+			backend: func(t *testing.T) *erc165Backend {
+				return newERC165Backend(t, SendCallConstructorInterfaceID)
+			},
+			expErr: ErrInvalidConstructorInterface,
+		},
+		{
+			name: "failure: contract claiming every interface",
+			backend: func(t *testing.T) *erc165Backend {
+				return newERC165Backend(t, erc165InterfaceID, invalidInterfaceID, SendCallConstructorInterfaceID)
+			},
+			expErr: ErrInvalidConstructorInterface,
+		},
+		{
+			name: "failure: no code",
+			backend: func(t *testing.T) *erc165Backend {
+				backend := newERC165Backend(t)
+				backend.code = nil
+				return backend
+			},
+			expErr: ErrInvalidConstructorInterface,
+		},
+		{
+			name: "failure: supportsInterface reverts with data",
+			backend: func(t *testing.T) *erc165Backend {
+				backend := newERC165Backend(t)
+				backend.callErr = revertError{}
+				return backend
+			},
+			expErr: ErrInvalidConstructorInterface,
+		},
+		{
+			name: "failure: supportsInterface reverts without reason",
+			backend: func(t *testing.T) *erc165Backend {
+				backend := newERC165Backend(t)
+				backend.callErr = errors.New("execution reverted")
+				return backend
+			},
+			expErr: ErrInvalidConstructorInterface,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckSendCallConstructor(&bind.CallOpts{}, tc.backend(t), constructor)
+			if tc.expErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.expErr)
+			require.ErrorContains(t, err, constructor.Hex())
+		})
+	}
+}
+
+func TestCheckSendCallConstructorTransportError(t *testing.T) {
+	transportErr := errors.New("connection refused")
+	backend := newERC165Backend(t)
+	backend.callErr = transportErr
+
+	err := CheckSendCallConstructor(&bind.CallOpts{}, backend, common.HexToAddress("0x03"))
+	require.ErrorIs(t, err, transportErr)
+	require.NotErrorIs(t, err, ErrInvalidConstructorInterface)
+}
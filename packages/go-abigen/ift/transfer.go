@@ -0,0 +1,56 @@
+package ift
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DefaultTimeoutWindow matches DEFAULT_TIMEOUT_DURATION, the window used by the iftTransfer
+// overload without a timeout.
+const DefaultTimeoutWindow = 15 * time.Minute
+
+// MaxTimeoutWindow matches ICS26Router's MAX_TIMEOUT_DURATION, the longest timeout sendPacket accepts
+// relative to the block timestamp.
+const MaxTimeoutWindow = 24 * time.Hour
+
+// ErrTimeoutWindowTooLong is returned for a timeout window ICS26Router would reject.
+var ErrTimeoutWindowTooLong = errors.New("timeout window exceeds the ICS26 maximum timeout duration")
+
+// TransferTimeout returns the timeout timestamp, in unix seconds, of a transfer sent at now.
+// A non-positive window falls back to DefaultTimeoutWindow, and a window above MaxTimeoutWindow is rejected.
+// The router measures the window from the block timestamp, so a window close to the maximum may still
+// revert if the transaction is included in a block older than now.
+func TransferTimeout(now time.Time, window time.Duration) (uint64, error) {
+	if window <= 0 {
+		window = DefaultTimeoutWindow
+	}
+	if window > MaxTimeoutWindow {
+		return 0, fmt.Errorf("%w: %s is above %s", ErrTimeoutWindowTooLong, window, MaxTimeoutWindow)
+	}
+	return uint64(now.Add(window).Unix()), nil
+}
+
+// IftTransferWithTimeoutWindow sends iftTransfer with an explicit timeout of now + window, so the
+// packet timeout does not depend on the contract default or on the block timestamp.
+func (_Contract *ContractTransactor) IftTransferWithTimeoutWindow(opts *bind.TransactOpts, clientId string, receiver string, amount *big.Int, now time.Time, window time.Duration) (*types.Transaction, error) {
+	timeout, err := TransferTimeout(now, window)
+	if err != nil {
+		return nil, err
+	}
+	return _Contract.IftTransfer(opts, clientId, receiver, amount, timeout)
+}
+
+// IftTransferWithTimeoutWindow sends iftTransfer with an explicit timeout of now + window using the session's transact options.
+func (_Contract *ContractSession) IftTransferWithTimeoutWindow(clientId string, receiver string, amount *big.Int, now time.Time, window time.Duration) (*types.Transaction, error) {
+	return _Contract.Contract.IftTransferWithTimeoutWindow(&_Contract.TransactOpts, clientId, receiver, amount, now, window)
+}
+
+// IftTransferWithTimeoutWindow sends iftTransfer with an explicit timeout of now + window using the session's transact options.
+func (_Contract *ContractTransactorSession) IftTransferWithTimeoutWindow(clientId string, receiver string, amount *big.Int, now time.Time, window time.Duration) (*types.Transaction, error) {
+	return _Contract.Contract.IftTransferWithTimeoutWindow(&_Contract.TransactOpts, clientId, receiver, amount, now, window)
+}
@@ -0,0 +1,60 @@
+package ift
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferTimeout(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	testCases := []struct {
+		name     string
+		window   time.Duration
+		expected uint64
+		expErr   error
+	}{
+		{name: "zero window uses the default", window: 0, expected: 1_700_000_000 + 15*60},
+		{name: "negative window uses the default", window: -time.Minute, expected: 1_700_000_000 + 15*60},
+		{name: "explicit window", window: time.Hour, expected: 1_700_000_000 + 3600},
+		{name: "maximum window", window: 24 * time.Hour, expected: 1_700_000_000 + 86400},
+		{name: "window above the maximum", window: 24*time.Hour + time.Second, expErr: ErrTimeoutWindowTooLong},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeout, err := TransferTimeout(now, tc.window)
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, timeout)
+		})
+	}
+}
+
+func TestIftTransferWithTimeoutWindow(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	transactor, opts := newNoSendTransactor(t)
+	tx, err := transactor.IftTransferWithTimeoutWindow(opts, "client-0", "cosmos1receiver", big.NewInt(100), now, time.Hour)
+	require.NoError(t, err)
+
+	parsed, err := ContractMetaData.GetAbi()
+	require.NoError(t, err)
+	method, err := parsed.MethodById(tx.Data())
+	require.NoError(t, err)
+	// The explicit overload is the one taking four arguments
+	require.Equal(t, "iftTransfer", method.Name)
+
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	require.NoError(t, err)
+	require.Equal(t, []any{"client-0", "cosmos1receiver", big.NewInt(100), uint64(1_700_000_000 + 3600)}, args)
+
+	_, err = transactor.IftTransferWithTimeoutWindow(opts, "client-0", "cosmos1receiver", big.NewInt(100), now, 48*time.Hour)
+	require.ErrorIs(t, err, ErrTimeoutWindowTooLong)
+}
@@ -8,12 +8,18 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/version"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
 const (
@@ -25,6 +31,20 @@ const (
 
 	// testExclusionsEnv is an optional env variable that can be used to exclude tests, or entire suites, from the output
 	testExclusionsEnv = "TEST_EXCLUSIONS"
+
+	// testInclusionsEnv is an optional env variable that can be used to restrict the output to the listed tests, or entire suites.
+	// Inclusions are applied first, exclusions then remove items from the included set.
+	testInclusionsEnv = "TEST_INCLUSIONS"
+
+	// directivePrefix marks comments carrying per-suite matrix annotations, e.g. `// testmatrix:go=1.23`
+	directivePrefix = "testmatrix:"
+	// goVersionDirective is the minimum Go version the suite requires
+	goVersionDirective = "go"
+	// timeoutDirective is the estimated duration of each of the suite's tests, used to order the matrix
+	timeoutDirective = "timeout"
+
+	// defaultNamingPattern is the testify convention suite entrypoint names are checked against with -enforce-naming
+	defaultNamingPattern = `^Test\w*Suite$`
 )
 
 type actionTestMatrix struct {
@@ -34,16 +54,84 @@ type actionTestMatrix struct {
 type testSuitePair struct {
 	Test       string `json:"test"`
 	EntryPoint string `json:"entrypoint"`
+	// Shard is the 1-based index of the entry among its suite's entries, only set with MaxTestsPerEntry
+	Shard int `json:"shard,omitempty"`
+	// Package is the directory of the suite's package relative to the test directory, only set with Packages
+	Package string `json:"package,omitempty"`
+}
+
+// suiteTest is a test discovered for a suite entrypoint, with the package directory it was found in
+type suiteTest struct {
+	Name    string
+	Package string
+}
+
+// matrixOptions controls which suites and tests end up in the generated matrix
+type matrixOptions struct {
+	// Suite, when set, restricts the output to a single suite entrypoint
+	Suite string
+	// IncludedItems, when non-empty, restricts the output to these suite entrypoints or `Suite/Test` pairs
+	IncludedItems []string
+	// ExcludedItems lists suite entrypoints or `Suite/Test` pairs to drop from the output
+	ExcludedItems []string
+	// Strict fails on duplicate (entrypoint, test) pairs instead of deduplicating them
+	Strict bool
+	// GoVersion, when set, excludes suites annotated with a higher minimum Go version
+	GoVersion string
+	// Subtests emits `Method/Subtest` entries for suite methods with string-literal `Run` subtests
+	Subtests bool
+	// StrictParse fails on the first test file that cannot be parsed instead of skipping it with a warning
+	StrictParse bool
+	// MaxTestsPerEntry, when positive, groups each suite's tests into entries of at most this many tests,
+	// with Test holding an anchored `^(Test_A|Test_B)$` alternation for `go test -run`
+	MaxTestsPerEntry int
+	// NamingPattern, when set, fails on suite entrypoints whose name does not match it
+	NamingPattern *regexp.Regexp
+	// CacheFile, when set, caches the suite and tests extracted from each file there, so that files unchanged
+	// since the previous run are not parsed again
+	CacheFile string
+	// Packages sets the Package of every entry, so that the runner can `go test` the suite's package directly
+	Packages bool
+	// Explain, when set, receives one line per test file naming the suite and tests it contributed or why it was skipped
+	Explain io.Writer
 }
 
 var (
 	ErrNoSuiteEntrypoint       = errors.New("no suite entrypoint found")
 	ErrMultipleSuiteEntrypoint = errors.New("multiple suite entrypoints found")
+	ErrDuplicateTests          = errors.New("duplicate suite tests found")
+	ErrInvalidGoVersion        = errors.New("invalid go version")
+	ErrInvalidTimeout          = errors.New("invalid timeout")
+	ErrShardedSubtests         = errors.New("sharding cannot be combined with subtests")
+	ErrSuiteNaming             = errors.New("suite entrypoint does not follow the naming convention")
+	ErrInvalidKeyMap           = errors.New("invalid key map")
 )
 
 func main() {
 	var testDir string
+	var strict bool
+	var goVersion string
+	var subtests bool
+	var strictParse bool
+	var explain bool
+	var maxTestsPerEntry int
+	var enforceNaming bool
+	var namingPattern string
+	var cacheFile string
+	var keyMapSpec string
+	var packages bool
 	flag.StringVar(&testDir, "dir", "", "Path to the test directory (required)")
+	flag.BoolVar(&strict, "strict", false, "Fail if the same suite test is discovered more than once")
+	flag.StringVar(&goVersion, "go-version", "", "Go version of the test runner, used to skip suites annotated with a higher `testmatrix:go` version")
+	flag.BoolVar(&subtests, "subtests", false, "Emit one entry per string-literal t.Run/s.Run subtest of a suite method")
+	flag.BoolVar(&explain, "explain", false, "Print to stderr which suite and tests each file contributed, or why it was skipped")
+	flag.BoolVar(&strictParse, "strict-parse", false, "Fail on the first test file that cannot be parsed instead of skipping it with a warning")
+	flag.IntVar(&maxTestsPerEntry, "max-tests-per-entry", 0, "Group each suite's tests into entries of at most this many tests, run together via a -run alternation")
+	flag.BoolVar(&enforceNaming, "enforce-naming", false, "Fail if a suite entrypoint name does not match -naming-pattern")
+	flag.StringVar(&namingPattern, "naming-pattern", defaultNamingPattern, "Regular expression suite entrypoint names must match with -enforce-naming")
+	flag.StringVar(&cacheFile, "cache", "", "File caching the suites and tests of unchanged test files between runs")
+	flag.StringVar(&keyMapSpec, "key-map", "", "Comma-separated `key=name` pairs renaming the test, entrypoint, shard or package key of each matrix entry")
+	flag.BoolVar(&packages, "packages", false, "Add the package directory of each suite, relative to -dir, to its entries")
 	flag.Parse()
 
 	if testDir == "" {
@@ -57,6 +145,16 @@ func main() {
 	if exclusions, ok := os.LookupEnv(testExclusionsEnv); ok {
 		excludedItems = strings.Split(exclusions, ",")
 	}
+	var includedItems []string
+	if inclusions, ok := os.LookupEnv(testInclusionsEnv); ok && inclusions != "" {
+		includedItems = strings.Split(inclusions, ",")
+	}
+
+	keyMap, err := parseKeyMap(keyMapSpec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: invalid -key-map: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Verify the test directory exists
 	if _, err := os.Stat(testDir); err != nil {
@@ -64,20 +162,142 @@ func main() {
 		os.Exit(1)
 	}
 
-	matrix, err := getGitHubActionMatrixForTests(testDir, suite, excludedItems)
+	opts := matrixOptions{
+		Suite:            suite,
+		IncludedItems:    includedItems,
+		ExcludedItems:    excludedItems,
+		Strict:           strict,
+		GoVersion:        goVersion,
+		Subtests:         subtests,
+		StrictParse:      strictParse,
+		MaxTestsPerEntry: maxTestsPerEntry,
+		CacheFile:        cacheFile,
+		Packages:         packages,
+	}
+	if explain {
+		opts.Explain = os.Stderr
+	}
+	if enforceNaming {
+		pattern, err := regexp.Compile(namingPattern)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: invalid -naming-pattern: %v\n", err)
+			os.Exit(1)
+		}
+		opts.NamingPattern = pattern
+	}
+
+	matrix, err := getGitHubActionMatrixForTests(testDir, opts)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "error generating GitHub Action JSON:", err)
 		os.Exit(1)
 	}
 
-	if err := json.NewEncoder(os.Stdout).Encode(matrix); err != nil {
+	if err := encodeMatrix(os.Stdout, matrix, keyMap); err != nil {
 		fmt.Fprintln(os.Stderr, "error writing JSON:", err)
 		os.Exit(1)
 	}
 }
 
-func getGitHubActionMatrixForTests(e2eRootDirectory, suite string, excludedItems []string) (actionTestMatrix, error) {
-	testSuiteMapping := map[string][]string{}
+// matrixKeys are the JSON keys of a matrix entry that -key-map can rename
+var matrixKeys = []string{"test", "entrypoint", "shard", "package"}
+
+// parseKeyMap parses a `test=name,entrypoint=suite` renaming of matrix entry keys. Every key must be one of
+// matrixKeys, and no two keys may end up with the same name, renamed or not.
+func parseKeyMap(spec string) (map[string]string, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	keyMap := map[string]string{}
+	for _, pair := range strings.Split(spec, ",") {
+		key, name, ok := strings.Cut(pair, "=")
+		key, name = strings.TrimSpace(key), strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: %q is not a key=name pair", ErrInvalidKeyMap, pair)
+		}
+		if !slices.Contains(matrixKeys, key) {
+			return nil, fmt.Errorf("%w: unknown key %q, expected one of %s", ErrInvalidKeyMap, key, strings.Join(matrixKeys, ", "))
+		}
+		if _, ok := keyMap[key]; ok {
+			return nil, fmt.Errorf("%w: key %q is renamed twice", ErrInvalidKeyMap, key)
+		}
+		keyMap[key] = name
+	}
+
+	renamedFrom := map[string]string{}
+	for _, key := range matrixKeys {
+		name := key
+		if renamed, ok := keyMap[key]; ok {
+			name = renamed
+		}
+		if other, ok := renamedFrom[name]; ok {
+			return nil, fmt.Errorf("%w: keys %q and %q would both be named %q", ErrInvalidKeyMap, other, key, name)
+		}
+		renamedFrom[name] = key
+	}
+
+	return keyMap, nil
+}
+
+// encodeMatrix writes the matrix as JSON, renaming the keys of each entry according to keyMap
+func encodeMatrix(w io.Writer, matrix actionTestMatrix, keyMap map[string]string) error {
+	if len(keyMap) == 0 {
+		return json.NewEncoder(w).Encode(matrix)
+	}
+
+	renamed := struct {
+		Include []map[string]any `json:"include"`
+	}{Include: make([]map[string]any, 0, len(matrix.Include))}
+	for _, pair := range matrix.Include {
+		bz, err := json.Marshal(pair)
+		if err != nil {
+			return err
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(bz, &fields); err != nil {
+			return err
+		}
+
+		entry := make(map[string]any, len(fields))
+		for key, value := range fields {
+			if name, ok := keyMap[key]; ok {
+				key = name
+			}
+			entry[key] = value
+		}
+		renamed.Include = append(renamed.Include, entry)
+	}
+
+	return json.NewEncoder(w).Encode(renamed)
+}
+
+func getGitHubActionMatrixForTests(e2eRootDirectory string, opts matrixOptions) (actionTestMatrix, error) {
+	testSuiteMapping := map[string][]suiteTest{}
+	suiteDurations := map[string]time.Duration{}
+
+	// A `Method/Subtest` name spans two -run levels, which an alternation inside parentheses cannot express
+	if opts.MaxTestsPerEntry > 0 && opts.Subtests {
+		return actionTestMatrix{}, ErrShardedSubtests
+	}
+
+	runnerGoVersion := ""
+	if opts.GoVersion != "" {
+		var err error
+		if runnerGoVersion, err = normalizeGoVersion(opts.GoVersion); err != nil {
+			return actionTestMatrix{}, fmt.Errorf("-go-version: %w", err)
+		}
+	}
+
+	explain := func(path, format string, args ...any) {
+		if opts.Explain != nil {
+			fmt.Fprintf(opts.Explain, "%s: %s\n", path, fmt.Sprintf(format, args...))
+		}
+	}
+
+	var cache *parseCache
+	if opts.CacheFile != "" {
+		cache = loadParseCache(opts.CacheFile)
+	}
 
 	fileSet := token.NewFileSet()
 	err := filepath.WalkDir(e2eRootDirectory, func(path string, d fs.DirEntry, err error) error {
@@ -89,27 +309,92 @@ func getGitHubActionMatrixForTests(e2eRootDirectory, suite string, excludedItems
 			return nil
 		}
 
-		astFile, err := parser.ParseFile(fileSet, path, nil, 0)
-		if err != nil {
-			return fmt.Errorf("parse file: %w", err)
+		var info fs.FileInfo
+		var summary fileSummary
+		cached := false
+		if cache != nil {
+			if info, err = d.Info(); err != nil {
+				return fmt.Errorf("walk e2e: %w", err)
+			}
+			summary, cached = cache.lookup(path, info, opts.Subtests)
 		}
 
-		suiteName, suiteTestCases, err := extractSuiteAndTestNames(astFile)
-		if err != nil {
-			// Ignore files without suite entrypoints (regular test files)
-			if errors.Is(err, ErrNoSuiteEntrypoint) {
+		if !cached {
+			astFile, err := parser.ParseFile(fileSet, path, nil, parser.ParseComments)
+			if err != nil {
+				if opts.StrictParse {
+					return fmt.Errorf("parse file: %w", err)
+				}
+				// A single broken file should not hide every other suite from the matrix
+				fmt.Fprintf(os.Stderr, "warning: skipping %s: %v\n", path, err)
+				explain(path, "skipped: parse error: %v", err)
 				return nil
 			}
-			// Propagate all other errors (like multiple suite entrypoints)
-			return fmt.Errorf("in file %s: %w", path, err)
+
+			if summary, err = summarizeFile(astFile, opts.Subtests); err != nil {
+				return fmt.Errorf("in file %s: %w", path, err)
+			}
+			if cache != nil {
+				cache.store(path, info, opts.Subtests, summary)
+			}
+		}
+
+		// Ignore files without suite entrypoints (regular test files)
+		if summary.Suite == "" {
+			explain(path, "skipped: no suite entrypoint")
+			return nil
+		}
+		suiteName, suiteTestCases := summary.Suite, summary.Tests
+
+		// Checked before any filtering so that drift is caught whatever subset of the matrix is generated
+		if opts.NamingPattern != nil && !opts.NamingPattern.MatchString(suiteName) {
+			return fmt.Errorf("in file %s: %w: %s does not match %s", path, ErrSuiteNaming, suiteName, opts.NamingPattern)
 		}
 
-		if slices.Contains(excludedItems, suiteName) {
+		if !isSuiteIncluded(opts.IncludedItems, suiteName) {
+			explain(path, "skipped: suite %s is not included by %s", suiteName, testInclusionsEnv)
 			return nil
 		}
 
-		if suite == "" || suiteName == suite {
-			testSuiteMapping[suiteName] = suiteTestCases
+		if slices.Contains(opts.ExcludedItems, suiteName) {
+			explain(path, "skipped: suite %s is excluded by %s", suiteName, testExclusionsEnv)
+			return nil
+		}
+
+		if runnerGoVersion != "" {
+			satisfied, err := satisfiesGoVersion(summary.Directives, runnerGoVersion)
+			if err != nil {
+				return fmt.Errorf("in file %s: %w", path, err)
+			}
+			if !satisfied {
+				explain(path, "skipped: suite %s requires a newer Go version than %s", suiteName, opts.GoVersion)
+				return nil
+			}
+		}
+
+		if opts.Suite == "" || suiteName == opts.Suite {
+			// The same entrypoint name may be discovered in several files (e.g. in different packages),
+			// so accumulate rather than overwrite and let duplicates be handled below.
+			var pkg string
+			if opts.Packages {
+				if pkg, err = filepath.Rel(e2eRootDirectory, filepath.Dir(path)); err != nil {
+					return fmt.Errorf("in file %s: %w", path, err)
+				}
+				pkg = filepath.ToSlash(pkg)
+			}
+			for _, testCase := range suiteTestCases {
+				testSuiteMapping[suiteName] = append(testSuiteMapping[suiteName], suiteTest{Name: testCase, Package: pkg})
+			}
+
+			duration, err := estimatedDuration(summary.Directives)
+			if err != nil {
+				return fmt.Errorf("in file %s: %w", path, err)
+			}
+			suiteDurations[suiteName] = max(suiteDurations[suiteName], duration)
+
+			explain(path, "suite %s: %s", suiteName, strings.Join(suiteTestCases, ", "))
+		} else {
+			explain(path, "skipped: suite %s does not match %s=%s", suiteName, testEntryPointEnv, opts.Suite)
 		}
 
 		return nil
@@ -118,44 +403,168 @@ func getGitHubActionMatrixForTests(e2eRootDirectory, suite string, excludedItems
 		return actionTestMatrix{}, err
 	}
 
+	if cache != nil {
+		if err := cache.save(); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: saving parse cache %s: %v\n", opts.CacheFile, err)
+		}
+	}
+
 	gh := actionTestMatrix{
 		Include: []testSuitePair{},
 	}
 
+	// Keyed by the full test name so that a suite copied into another package still counts as a duplicate
+	seenTests := make(map[string]bool)
+	var duplicates []string
 	for testSuiteName, testCases := range testSuiteMapping {
-		for _, testCaseName := range testCases {
-			// Check if this specific test is excluded
+		for _, testCase := range testCases {
+			testCaseName := testCase.Name
 			fullTestName := fmt.Sprintf("%s/%s", testSuiteName, testCaseName)
-			if slices.Contains(excludedItems, fullTestName) {
+			if !isTestIncluded(opts.IncludedItems, testSuiteName, fullTestName) {
 				continue
 			}
 
+			// Check if this specific test, or the method of a subtest, is excluded
+			if slices.ContainsFunc(opts.ExcludedItems, func(item string) bool { return matchesTest(item, fullTestName) }) {
+				continue
+			}
+
+			// With Packages, same-named suites in different packages are distinct entries run with their own
+			// `go test`, so only a repeat within one package is a duplicate
+			seenKey := fullTestName
+			if testCase.Package != "" {
+				seenKey = testCase.Package + ":" + fullTestName
+			}
+			if seenTests[seenKey] {
+				duplicates = append(duplicates, fullTestName)
+				continue
+			}
+			seenTests[seenKey] = true
+
 			gh.Include = append(gh.Include, testSuitePair{
 				Test:       testCaseName,
 				EntryPoint: testSuiteName,
+				Package:    testCase.Package,
 			})
 		}
 	}
 
+	if opts.Strict && len(duplicates) > 0 {
+		sort.Strings(duplicates)
+		return actionTestMatrix{}, fmt.Errorf("%w: %s", ErrDuplicateTests, strings.Join(duplicates, ", "))
+	}
+
 	if len(gh.Include) == 0 {
 		return actionTestMatrix{}, errors.New("no test cases found")
 	}
 
+	// Longest tests first so that shard schedulers do not leave them as stragglers, then by name
 	sort.Slice(gh.Include, func(i, j int) bool {
+		durationI, durationJ := suiteDurations[gh.Include[i].EntryPoint], suiteDurations[gh.Include[j].EntryPoint]
+		if durationI != durationJ {
+			return durationI > durationJ
+		}
 		if gh.Include[i].EntryPoint != gh.Include[j].EntryPoint {
 			return gh.Include[i].EntryPoint < gh.Include[j].EntryPoint
 		}
+		// Keeps each package's tests together for shardEntries when an entrypoint is found in several packages
+		if gh.Include[i].Package != gh.Include[j].Package {
+			return gh.Include[i].Package < gh.Include[j].Package
+		}
 		if gh.Include[i].Test != gh.Include[j].Test {
 			return gh.Include[i].Test < gh.Include[j].Test
 		}
 		return false
 	})
 
+	if opts.MaxTestsPerEntry > 0 {
+		gh.Include = shardEntries(gh.Include, opts.MaxTestsPerEntry)
+	}
+
 	return gh, nil
 }
 
+// shardEntries groups consecutive entries of the same suite into entries of at most maxTests tests,
+// keeping the order of the input, which lists each suite's tests together. The alternation is anchored at
+// both ends, as -run matches unanchored and `(Test_A)` would also select e.g. `Test_AB` of another shard.
+func shardEntries(entries []testSuitePair, maxTests int) []testSuitePair {
+	var sharded []testSuitePair
+	for start := 0; start < len(entries); {
+		entryPoint, pkg := entries[start].EntryPoint, entries[start].Package
+		end := start
+		for end < len(entries) && entries[end].EntryPoint == entryPoint && entries[end].Package == pkg {
+			end++
+		}
+
+		for shard, tests := range slices.Collect(slices.Chunk(entries[start:end], maxTests)) {
+			names := make([]string, 0, len(tests))
+			for _, test := range tests {
+				names = append(names, test.Test)
+			}
+
+			sharded = append(sharded, testSuitePair{
+				Test:       "^(" + strings.Join(names, "|") + ")$",
+				EntryPoint: entryPoint,
+				Shard:      shard + 1,
+				Package:    pkg,
+			})
+		}
+		start = end
+	}
+
+	return sharded
+}
+
+// isSuiteIncluded reports whether any of the suite's tests can pass the inclusion list.
+// An empty list includes everything.
+func isSuiteIncluded(includedItems []string, suiteName string) bool {
+	if len(includedItems) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(includedItems, func(item string) bool {
+		return item == suiteName || strings.HasPrefix(item, suiteName+"/")
+	})
+}
+
+// isTestIncluded reports whether a test passes the inclusion list, either listed itself or via its whole suite.
+// An empty list includes everything.
+func isTestIncluded(includedItems []string, suiteName, fullTestName string) bool {
+	if len(includedItems) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(includedItems, func(item string) bool {
+		return item == suiteName || matchesTest(item, fullTestName)
+	})
+}
+
+// matchesTest reports whether a `Suite/Test` item selects the test, including the subtests of a listed method.
+func matchesTest(item, fullTestName string) bool {
+	return item == fullTestName || strings.HasPrefix(fullTestName, item+"/")
+}
+
+// summarizeFile extracts the suite, tests and directives of a test file. Files without a suite entrypoint
+// yield an empty summary, all other extraction errors (like multiple suite entrypoints) are returned.
+func summarizeFile(file *ast.File, subtests bool) (fileSummary, error) {
+	suiteName, testNames, err := extractSuiteAndTestNames(file, subtests)
+	if errors.Is(err, ErrNoSuiteEntrypoint) {
+		return fileSummary{}, nil
+	}
+	if err != nil {
+		return fileSummary{}, err
+	}
+
+	return fileSummary{
+		Suite:      suiteName,
+		Tests:      testNames,
+		Directives: extractDirectives(file),
+	}, nil
+}
+
 // extractSuiteAndTestNames extracts the suite name and test names from a Go file by parsing the AST.
-func extractSuiteAndTestNames(file *ast.File) (string, []string, error) {
+// With subtests set, methods with string-literal subtests are replaced by their `Method/Subtest` names.
+func extractSuiteAndTestNames(file *ast.File, subtests bool) (string, []string, error) {
 	suiteName := ""
 	testNames := []string{}
 
@@ -174,7 +583,11 @@ func extractSuiteAndTestNames(file *ast.File) (string, []string, error) {
 			}
 			suiteName = fnName
 		case isSuiteTest(fn):
-			testNames = append(testNames, fnName)
+			if !subtests {
+				testNames = append(testNames, fnName)
+				continue
+			}
+			testNames = append(testNames, extractSubtestNames(fn)...)
 		}
 	}
 
@@ -185,6 +598,144 @@ func extractSuiteAndTestNames(file *ast.File) (string, []string, error) {
 	return suiteName, testNames, nil
 }
 
+// extractSubtestNames returns `Method/Subtest` names for the top-level `Run("name", func...)` calls in a
+// suite method, or just the method name if it has none. If any subtest name is not a string literal the
+// method cannot be split safely, so a warning is printed and the method is kept whole.
+func extractSubtestNames(fn *ast.FuncDecl) []string {
+	methodName := fn.Name.Name
+	if fn.Body == nil {
+		return []string{methodName}
+	}
+
+	var names []string
+	dynamic := false
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || !isRunCall(call) {
+			return true
+		}
+
+		literal, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			dynamic = true
+			return false
+		}
+
+		name, err := strconv.Unquote(literal.Value)
+		if err != nil {
+			dynamic = true
+			return false
+		}
+
+		names = append(names, fmt.Sprintf("%s/%s", methodName, subtestRunPattern(name)))
+
+		// Nested subtests run as part of their parent
+		return false
+	})
+
+	if dynamic {
+		fmt.Fprintf(os.Stderr, "warning: %s has subtests with non-literal names, keeping it as a single test\n", methodName)
+		return []string{methodName}
+	}
+	if len(names) == 0 {
+		return []string{methodName}
+	}
+
+	return names
+}
+
+// subtestRunPattern returns the -run pattern matching exactly the subtest with the given name. go test rewrites
+// the name, turning spaces into underscores and escaping unprintable runes, and -run matches each `/`-separated
+// level of it as a regular expression, so every level of the rewritten name is quoted.
+func subtestRunPattern(name string) string {
+	var rewritten strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsSpace(r):
+			rewritten.WriteByte('_')
+		case !strconv.IsPrint(r):
+			quoted := strconv.QuoteRune(r)
+			rewritten.WriteString(quoted[1 : len(quoted)-1])
+		default:
+			rewritten.WriteRune(r)
+		}
+	}
+
+	levels := strings.Split(rewritten.String(), "/")
+	for i, level := range levels {
+		levels[i] = regexp.QuoteMeta(level)
+	}
+	return strings.Join(levels, "/")
+}
+
+// isRunCall reports whether the call looks like `t.Run(name, func...)` or `s.Run(name, func...)`.
+func isRunCall(call *ast.CallExpr) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Run" || len(call.Args) != 2 {
+		return false
+	}
+
+	_, ok = call.Args[1].(*ast.FuncLit)
+	return ok
+}
+
+// extractDirectives collects `// testmatrix:key=value` annotations from all comments in the file.
+func extractDirectives(file *ast.File) map[string]string {
+	directives := map[string]string{}
+	for _, commentGroup := range file.Comments {
+		for _, comment := range commentGroup.List {
+			text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+			directive, found := strings.CutPrefix(text, directivePrefix)
+			if !found {
+				continue
+			}
+
+			key, value, _ := strings.Cut(directive, "=")
+			directives[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return directives
+}
+
+// satisfiesGoVersion reports whether the runner's Go version meets the suite's `testmatrix:go` annotation, if any.
+func satisfiesGoVersion(directives map[string]string, runnerGoVersion string) (bool, error) {
+	required, ok := directives[goVersionDirective]
+	if !ok {
+		return true, nil
+	}
+
+	requiredGoVersion, err := normalizeGoVersion(required)
+	if err != nil {
+		return false, fmt.Errorf("%s%s annotation: %w", directivePrefix, goVersionDirective, err)
+	}
+
+	return version.Compare(runnerGoVersion, requiredGoVersion) >= 0, nil
+}
+
+// estimatedDuration returns the suite's `testmatrix:timeout` annotation, or zero when it has none.
+func estimatedDuration(directives map[string]string) (time.Duration, error) {
+	timeout, ok := directives[timeoutDirective]
+	if !ok {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(timeout)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("%s%s annotation: %w: %q", directivePrefix, timeoutDirective, ErrInvalidTimeout, timeout)
+	}
+	return duration, nil
+}
+
+// normalizeGoVersion converts versions such as `1.23` or `go1.23.4` to the `go1.23.4` form used by go/version.
+func normalizeGoVersion(v string) (string, error) {
+	normalized := "go" + strings.TrimPrefix(strings.TrimSpace(v), "go")
+	if !version.IsValid(normalized) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidGoVersion, v)
+	}
+	return normalized, nil
+}
+
 func isSuiteEntrypoint(f *ast.FuncDecl) bool {
 	if !isTestFunction(f) {
 		return false
@@ -274,3 +825,113 @@ func isSuiteTest(fn *ast.FuncDecl) bool {
 
 	return strings.HasSuffix(receiverIdent.Name, "TestSuite") || strings.HasSuffix(receiverIdent.Name, "Suite")
 }
+
+// parseCacheVersion is bumped whenever fileSummary changes, so that stale caches are discarded
+const parseCacheVersion = 1
+
+// fileSummary is what the matrix needs from a test file, so that a cached file does not have to be parsed again
+type fileSummary struct {
+	// Suite is the suite entrypoint, empty for files without one
+	Suite      string            `json:"suite,omitempty"`
+	Tests      []string          `json:"tests,omitempty"`
+	Directives map[string]string `json:"directives,omitempty"`
+}
+
+// parseCacheEntry is a file summary together with the file state and options it was extracted with
+type parseCacheEntry struct {
+	ModTime  int64       `json:"modTime"`
+	Size     int64       `json:"size"`
+	Subtests bool        `json:"subtests"`
+	Summary  fileSummary `json:"summary"`
+}
+
+// parseCache is an on-disk cache of file summaries keyed by path. An entry is only used while the file's
+// modification time and size, and the -subtests mode, are those it was stored with.
+type parseCache struct {
+	Version int                        `json:"version"`
+	Entries map[string]parseCacheEntry `json:"entries"`
+
+	path string
+	// seen holds the entries looked up or stored in this run; only those are saved, pruning deleted files
+	seen map[string]bool
+}
+
+// loadParseCache reads the cache at path. A missing, unreadable or outdated cache starts out empty, since the
+// cache only ever saves work.
+func loadParseCache(path string) *parseCache {
+	cache := &parseCache{
+		Version: parseCacheVersion,
+		Entries: map[string]parseCacheEntry{},
+		path:    path,
+		seen:    map[string]bool{},
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "warning: ignoring parse cache %s: %v\n", path, err)
+		}
+		return cache
+	}
+
+	var stored parseCache
+	if err := json.Unmarshal(data, &stored); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: ignoring parse cache %s: %v\n", path, err)
+		return cache
+	}
+	if stored.Version == parseCacheVersion && stored.Entries != nil {
+		cache.Entries = stored.Entries
+	}
+
+	return cache
+}
+
+// lookup returns the cached summary of the file if it is unchanged since it was stored
+func (c *parseCache) lookup(path string, info fs.FileInfo, subtests bool) (fileSummary, bool) {
+	entry, ok := c.Entries[path]
+	if !ok || entry.ModTime != info.ModTime().UnixNano() || entry.Size != info.Size() || entry.Subtests != subtests {
+		return fileSummary{}, false
+	}
+
+	c.seen[path] = true
+	return entry.Summary, true
+}
+
+func (c *parseCache) store(path string, info fs.FileInfo, subtests bool, summary fileSummary) {
+	c.Entries[path] = parseCacheEntry{
+		ModTime:  info.ModTime().UnixNano(),
+		Size:     info.Size(),
+		Subtests: subtests,
+		Summary:  summary,
+	}
+	c.seen[path] = true
+}
+
+// save writes the entries used in this run, replacing the cache file atomically
+func (c *parseCache) save() error {
+	for path := range c.Entries {
+		if !c.seen[path] {
+			delete(c.Entries, path)
+		}
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
@@ -5,8 +5,12 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,7 +19,7 @@ import (
 func TestGetGitHubActionMatrixForTests(t *testing.T) {
 	e2eDir := filepath.Clean(filepath.Join("..", "..", "e2e", "interchaintestv8"))
 
-	matrix, err := getGitHubActionMatrixForTests(e2eDir, "", nil)
+	matrix, err := getGitHubActionMatrixForTests(e2eDir, matrixOptions{})
 	require.NoError(t, err)
 
 	assert.NotEmpty(t, matrix.Include, "Should discover tests")
@@ -41,7 +45,7 @@ func TestFilterBySuiteEntrypoint(t *testing.T) {
 	e2eDir := filepath.Clean(filepath.Join("..", "..", "e2e", "interchaintestv8"))
 
 	suiteName := "TestWithSP1ICS07TendermintTestSuite"
-	matrix, err := getGitHubActionMatrixForTests(e2eDir, suiteName, nil)
+	matrix, err := getGitHubActionMatrixForTests(e2eDir, matrixOptions{Suite: suiteName})
 	require.NoError(t, err)
 
 	assert.True(t, len(matrix.Include) >= 1, "Should have at least 1 test when filtering by suite")
@@ -55,7 +59,7 @@ func TestFilterByExclusions(t *testing.T) {
 	e2eDir := filepath.Clean(filepath.Join("..", "..", "e2e", "interchaintestv8"))
 
 	excludedSuites := []string{"TestWithProofAPITestSuite"}
-	matrix, err := getGitHubActionMatrixForTests(e2eDir, "", excludedSuites)
+	matrix, err := getGitHubActionMatrixForTests(e2eDir, matrixOptions{ExcludedItems: excludedSuites})
 	require.NoError(t, err)
 
 	for _, test := range matrix.Include {
@@ -63,6 +67,244 @@ func TestFilterByExclusions(t *testing.T) {
 	}
 }
 
+func TestFilterByInclusions(t *testing.T) {
+	e2eDir := filepath.Clean(filepath.Join("..", "..", "e2e", "interchaintestv8"))
+
+	testCases := []struct {
+		name     string
+		included []string
+		excluded []string
+		assert   func(t *testing.T, matrix actionTestMatrix)
+	}{
+		{
+			name:     "whole suite",
+			included: []string{"TestWithSP1ICS07TendermintTestSuite"},
+			assert: func(t *testing.T, matrix actionTestMatrix) {
+				t.Helper()
+				require.NotEmpty(t, matrix.Include)
+				for _, test := range matrix.Include {
+					assert.Equal(t, "TestWithSP1ICS07TendermintTestSuite", test.EntryPoint)
+				}
+			},
+		},
+		{
+			name:     "single test and whole suite",
+			included: []string{"TestWithIbcEurekaTestSuite/Test_Deploy", "TestWithSP1ICS07TendermintTestSuite"},
+			assert: func(t *testing.T, matrix actionTestMatrix) {
+				t.Helper()
+				var eurekaTests []string
+				for _, test := range matrix.Include {
+					if test.EntryPoint == "TestWithIbcEurekaTestSuite" {
+						eurekaTests = append(eurekaTests, test.Test)
+					}
+				}
+				assert.Equal(t, []string{"Test_Deploy"}, eurekaTests)
+				assert.Greater(t, len(matrix.Include), 1)
+			},
+		},
+		{
+			name:     "exclusions remove from included suite",
+			included: []string{"TestWithSP1ICS07TendermintTestSuite"},
+			excluded: []string{"TestWithSP1ICS07TendermintTestSuite/Test_UpdateClient"},
+			assert: func(t *testing.T, matrix actionTestMatrix) {
+				t.Helper()
+				require.NotEmpty(t, matrix.Include)
+				for _, test := range matrix.Include {
+					assert.Equal(t, "TestWithSP1ICS07TendermintTestSuite", test.EntryPoint)
+					assert.NotEqual(t, "Test_UpdateClient", test.Test)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matrix, err := getGitHubActionMatrixForTests(e2eDir, matrixOptions{IncludedItems: tc.included, ExcludedItems: tc.excluded})
+			require.NoError(t, err)
+			tc.assert(t, matrix)
+		})
+	}
+
+	// Excluding everything that was included leaves nothing
+	_, err := getGitHubActionMatrixForTests(e2eDir, matrixOptions{
+		IncludedItems: []string{"TestWithIbcEurekaTestSuite/Test_Deploy"},
+		ExcludedItems: []string{"TestWithIbcEurekaTestSuite"},
+	})
+	require.Error(t, err)
+}
+
+func TestDuplicateSuiteTests(t *testing.T) {
+	fixtureDir := filepath.Join("testdata", "duplicates")
+
+	matrix, err := getGitHubActionMatrixForTests(fixtureDir, matrixOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []testSuitePair{
+		{Test: "Test_OnlyFirst", EntryPoint: "TestWithDuplicateTestSuite"},
+		{Test: "Test_OnlySecond", EntryPoint: "TestWithDuplicateTestSuite"},
+		{Test: "Test_Shared", EntryPoint: "TestWithDuplicateTestSuite"},
+	}, matrix.Include, "Duplicates should be removed silently by default")
+
+	_, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{Strict: true})
+	require.ErrorIs(t, err, ErrDuplicateTests)
+	assert.Contains(t, err.Error(), "TestWithDuplicateTestSuite/Test_Shared")
+	assert.NotContains(t, err.Error(), "Test_OnlyFirst")
+}
+
+func TestGoVersionGate(t *testing.T) {
+	fixtureDir := filepath.Join("testdata", "goversion")
+
+	entrypoints := func(matrix actionTestMatrix) []string {
+		var names []string
+		for _, test := range matrix.Include {
+			names = append(names, test.EntryPoint)
+		}
+		return names
+	}
+
+	matrix, err := getGitHubActionMatrixForTests(fixtureDir, matrixOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"TestWithGoSatisfiedTestSuite",
+		"TestWithGoUnannotatedTestSuite",
+		"TestWithGoUnsatisfiedTestSuite",
+	}, entrypoints(matrix), "Without -go-version no suite should be gated")
+
+	for _, goVersion := range []string{"1.23", "go1.23.4"} {
+		matrix, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{GoVersion: goVersion})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"TestWithGoSatisfiedTestSuite",
+			"TestWithGoUnannotatedTestSuite",
+		}, entrypoints(matrix), "Suites requiring a newer Go should be excluded for %s", goVersion)
+	}
+
+	matrix, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{GoVersion: "1.99.1"})
+	require.NoError(t, err)
+	assert.Len(t, matrix.Include, 3, "An exactly matching version satisfies the gate")
+
+	_, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{GoVersion: "latest"})
+	require.ErrorIs(t, err, ErrInvalidGoVersion)
+}
+
+func TestSubtests(t *testing.T) {
+	fixtureDir := filepath.Join("testdata", "subtests")
+
+	matrix, err := getGitHubActionMatrixForTests(fixtureDir, matrixOptions{})
+	require.NoError(t, err)
+	assert.Len(t, matrix.Include, 3, "Without subtests only the methods should be listed")
+
+	matrix, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{Subtests: true})
+	require.NoError(t, err)
+
+	var tests []string
+	for _, test := range matrix.Include {
+		assert.Equal(t, "TestWithSubtestTestSuite", test.EntryPoint)
+		tests = append(tests, test.Test)
+	}
+	assert.Equal(t, []string{
+		"Test_Dynamic",
+		"Test_Literal/first_case",
+		"Test_Literal/second_case",
+		`Test_Literal/v1\.2_\(legacy\)`,
+		"Test_NoSubtests",
+	}, tests, "Literal subtests should be split, dynamic ones keep the method whole")
+
+	// Listing the method selects all of its subtests
+	matrix, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{
+		Subtests:      true,
+		IncludedItems: []string{"TestWithSubtestTestSuite/Test_Literal"},
+		ExcludedItems: []string{"TestWithSubtestTestSuite/Test_Literal/second_case"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []testSuitePair{
+		{Test: "Test_Literal/first_case", EntryPoint: "TestWithSubtestTestSuite"},
+		{Test: `Test_Literal/v1\.2_\(legacy\)`, EntryPoint: "TestWithSubtestTestSuite"},
+	}, matrix.Include)
+}
+
+func TestSubtestRunPattern(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "plain", expected: "plain"},
+		{name: "first case", expected: "first_case"},
+		{name: "tab\tand newline\n", expected: "tab_and_newline_"},
+		{name: "v1.2 (legacy)", expected: `v1\.2_\(legacy\)`},
+		{name: "a+b/[c]", expected: `a\+b/\[c\]`},
+		{name: "bell\a", expected: `bell\\a`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, subtestRunPattern(tc.name))
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	fixtureDir := filepath.Join("testdata", "parseerrors")
+
+	matrix, err := getGitHubActionMatrixForTests(fixtureDir, matrixOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []testSuitePair{{Test: "Test_Valid", EntryPoint: "TestWithParseErrorsTestSuite"}}, matrix.Include,
+		"Unparseable files should be skipped without hiding the valid suites")
+
+	_, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{StrictParse: true})
+	require.ErrorContains(t, err, "parse file")
+	assert.Contains(t, err.Error(), "broken_test.go")
+
+	// Skipping still fails when nothing parseable is left
+	_, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{Suite: "TestWithBrokenTestSuite"})
+	require.Error(t, err)
+}
+
+func TestEstimatedDurationOrder(t *testing.T) {
+	fixtureDir := filepath.Join("testdata", "durations")
+
+	matrix, err := getGitHubActionMatrixForTests(fixtureDir, matrixOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []testSuitePair{
+		{Test: "Test_A", EntryPoint: "TestWithSlowTestSuite"},
+		{Test: "Test_B", EntryPoint: "TestWithSlowTestSuite"},
+		{Test: "Test_A", EntryPoint: "TestWithFastTestSuite"},
+		{Test: "Test_B", EntryPoint: "TestWithFastTestSuite"},
+		{Test: "Test_A", EntryPoint: "TestWithUnannotatedTestSuite"},
+		{Test: "Test_B", EntryPoint: "TestWithUnannotatedTestSuite"},
+	}, matrix.Include, "Suites should be ordered by descending timeout, unannotated ones last by name")
+
+	invalidDir := t.TempDir()
+	data, err := os.ReadFile(filepath.Join(fixtureDir, "fast", "fast_test.go"))
+	require.NoError(t, err)
+	invalid := strings.Replace(string(data), "timeout=5m", "timeout=soon", 1)
+	require.NoError(t, os.WriteFile(filepath.Join(invalidDir, "invalid_test.go"), []byte(invalid), 0o600))
+
+	_, err = getGitHubActionMatrixForTests(invalidDir, matrixOptions{})
+	require.ErrorIs(t, err, ErrInvalidTimeout)
+}
+
+func TestExplain(t *testing.T) {
+	var explain strings.Builder
+	_, err := getGitHubActionMatrixForTests(filepath.Join("testdata", "parseerrors"), matrixOptions{Explain: &explain})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(explain.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], filepath.Join("parseerrors", "broken_test.go")+": skipped: parse error: ")
+	assert.Equal(t, filepath.Join("testdata", "parseerrors", "valid_test.go")+": suite TestWithParseErrorsTestSuite: Test_Valid", lines[1])
+
+	explain.Reset()
+	_, err = getGitHubActionMatrixForTests(filepath.Join("testdata", "goversion"), matrixOptions{
+		Explain:       &explain,
+		ExcludedItems: []string{"TestWithGoSatisfiedTestSuite"},
+		GoVersion:     "1.23",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, explain.String(), "satisfied_test.go: skipped: suite TestWithGoSatisfiedTestSuite is excluded by TEST_EXCLUSIONS\n")
+	assert.Contains(t, explain.String(), "unsatisfied_test.go: skipped: suite TestWithGoUnsatisfiedTestSuite requires a newer Go version than 1.23\n")
+	assert.Contains(t, explain.String(), "unannotated_test.go: suite TestWithGoUnannotatedTestSuite: Test_Something\n")
+}
+
 func TestJSONOutput(t *testing.T) {
 	testPairs := []testSuitePair{
 		{Test: "Test_Deploy", EntryPoint: "TestWithIbcEurekaTestSuite"},
@@ -214,3 +456,191 @@ func (s *MyStruct) TestSomething() {}`,
 		})
 	}
 }
+
+func TestMaxTestsPerEntry(t *testing.T) {
+	fixtureDir := filepath.Join("testdata", "shards")
+
+	matrix, err := getGitHubActionMatrixForTests(fixtureDir, matrixOptions{MaxTestsPerEntry: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []testSuitePair{
+		{Test: "^(Test_A|Test_B)$", EntryPoint: "TestWithShardsTestSuite", Shard: 1},
+		{Test: "^(Test_C|Test_D)$", EntryPoint: "TestWithShardsTestSuite", Shard: 2},
+		{Test: "^(Test_E)$", EntryPoint: "TestWithShardsTestSuite", Shard: 3},
+	}, matrix.Include)
+
+	// Each suite is sharded on its own and keeps its place in the duration order
+	matrix, err = getGitHubActionMatrixForTests(filepath.Join("testdata", "durations"), matrixOptions{MaxTestsPerEntry: 5})
+	require.NoError(t, err)
+	assert.Equal(t, []testSuitePair{
+		{Test: "^(Test_A|Test_B)$", EntryPoint: "TestWithSlowTestSuite", Shard: 1},
+		{Test: "^(Test_A|Test_B)$", EntryPoint: "TestWithFastTestSuite", Shard: 1},
+		{Test: "^(Test_A|Test_B)$", EntryPoint: "TestWithUnannotatedTestSuite", Shard: 1},
+	}, matrix.Include)
+
+	_, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{MaxTestsPerEntry: 2, Subtests: true})
+	require.ErrorIs(t, err, ErrShardedSubtests)
+}
+
+func TestShardEntriesAnchored(t *testing.T) {
+	names := []string{"Test_Send", "Test_Other", "Test_Retry_Test_Send"}
+	var entries []testSuitePair
+	for _, name := range names {
+		entries = append(entries, testSuitePair{Test: name, EntryPoint: "TestWithShardsTestSuite"})
+	}
+
+	shards := shardEntries(entries, 2)
+	require.Len(t, shards, 2)
+
+	// Each test must be selected by exactly one shard with the workflows' `^E$/${test}$` form
+	for _, name := range names {
+		var matches int
+		for _, shard := range shards {
+			if regexp.MustCompile(shard.Test + "$").MatchString(name) {
+				matches++
+			}
+		}
+		assert.Equal(t, 1, matches, name)
+	}
+}
+
+func TestPackages(t *testing.T) {
+	fixtureDir := filepath.Join("testdata", "packages")
+
+	matrix, err := getGitHubActionMatrixForTests(fixtureDir, matrixOptions{Packages: true})
+	require.NoError(t, err)
+	assert.Equal(t, []testSuitePair{
+		{Test: "Test_A", EntryPoint: "TestWithInnerTestSuite", Package: "nested/inner"},
+		{Test: "Test_B", EntryPoint: "TestWithInnerTestSuite", Package: "nested/inner"},
+		{Test: "Test_Top", EntryPoint: "TestWithTopTestSuite", Package: "."},
+	}, matrix.Include)
+
+	// Shards carry the package of their tests
+	matrix, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{Packages: true, MaxTestsPerEntry: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []testSuitePair{
+		{Test: "^(Test_A|Test_B)$", EntryPoint: "TestWithInnerTestSuite", Shard: 1, Package: "nested/inner"},
+		{Test: "^(Test_Top)$", EntryPoint: "TestWithTopTestSuite", Shard: 1, Package: "."},
+	}, matrix.Include)
+
+	// Same-named suites in different packages are kept apart, as each package is tested on its own
+	matrix, err = getGitHubActionMatrixForTests(filepath.Join("testdata", "duplicates"), matrixOptions{Packages: true, Strict: true})
+	require.NoError(t, err)
+	assert.Equal(t, []testSuitePair{
+		{Test: "Test_OnlyFirst", EntryPoint: "TestWithDuplicateTestSuite", Package: "first"},
+		{Test: "Test_Shared", EntryPoint: "TestWithDuplicateTestSuite", Package: "first"},
+		{Test: "Test_OnlySecond", EntryPoint: "TestWithDuplicateTestSuite", Package: "second"},
+		{Test: "Test_Shared", EntryPoint: "TestWithDuplicateTestSuite", Package: "second"},
+	}, matrix.Include)
+
+	// Without the option the key is left out
+	matrix, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{})
+	require.NoError(t, err)
+	bz, err := json.Marshal(matrix)
+	require.NoError(t, err)
+	assert.NotContains(t, string(bz), "package")
+}
+
+func TestEnforceNaming(t *testing.T) {
+	fixtureDir := filepath.Join("testdata", "naming")
+	conventional := regexp.MustCompile(defaultNamingPattern)
+
+	// Off by default
+	matrix, err := getGitHubActionMatrixForTests(fixtureDir, matrixOptions{})
+	require.NoError(t, err)
+	assert.Len(t, matrix.Include, 2)
+
+	matrix, err = getGitHubActionMatrixForTests(filepath.Join(fixtureDir, "conforming"), matrixOptions{NamingPattern: conventional})
+	require.NoError(t, err)
+	assert.Equal(t, []testSuitePair{{Test: "Test_A", EntryPoint: "TestWithConformingTestSuite"}}, matrix.Include)
+
+	_, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{NamingPattern: conventional})
+	require.ErrorIs(t, err, ErrSuiteNaming)
+	require.ErrorContains(t, err, "TestNonConforming")
+
+	// Excluding the suite does not hide the drift
+	_, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{NamingPattern: conventional, ExcludedItems: []string{"TestNonConforming"}})
+	require.ErrorIs(t, err, ErrSuiteNaming)
+
+	matrix, err = getGitHubActionMatrixForTests(fixtureDir, matrixOptions{NamingPattern: regexp.MustCompile(`^Test`)})
+	require.NoError(t, err)
+	assert.Len(t, matrix.Include, 2)
+}
+
+func TestParseCache(t *testing.T) {
+	fixtureDir := t.TempDir()
+	testFile := filepath.Join(fixtureDir, "fast_test.go")
+	source, err := os.ReadFile(filepath.Join("testdata", "durations", "fast", "fast_test.go"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(testFile, source, 0o600))
+
+	opts := matrixOptions{CacheFile: filepath.Join(t.TempDir(), "cache.json")}
+	first, err := getGitHubActionMatrixForTests(fixtureDir, opts)
+	require.NoError(t, err)
+	require.FileExists(t, opts.CacheFile)
+
+	// Unparsable content of the same size and modification time is only missed if the cache is used
+	info, err := os.Stat(testFile)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(testFile, []byte(strings.Repeat("!", len(source))), 0o600))
+	require.NoError(t, os.Chtimes(testFile, info.ModTime(), info.ModTime()))
+
+	second, err := getGitHubActionMatrixForTests(fixtureDir, opts)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	// Any change to the modification time invalidates the entry
+	later := info.ModTime().Add(time.Second)
+	require.NoError(t, os.Chtimes(testFile, later, later))
+	opts.StrictParse = true
+	_, err = getGitHubActionMatrixForTests(fixtureDir, opts)
+	require.ErrorContains(t, err, "parse file")
+
+	// A corrupt cache is ignored
+	require.NoError(t, os.WriteFile(testFile, source, 0o600))
+	require.NoError(t, os.WriteFile(opts.CacheFile, []byte("{"), 0o600))
+	third, err := getGitHubActionMatrixForTests(fixtureDir, opts)
+	require.NoError(t, err)
+	assert.Equal(t, first, third)
+}
+
+func TestKeyMap(t *testing.T) {
+	matrix := actionTestMatrix{Include: []testSuitePair{
+		{Test: "Test_A", EntryPoint: "TestWithShardedTestSuite", Shard: 1},
+		{Test: "Test_B", EntryPoint: "TestWithShardedTestSuite"},
+	}}
+
+	keyMap, err := parseKeyMap("test=name, entrypoint=suite")
+	require.NoError(t, err)
+
+	var out strings.Builder
+	require.NoError(t, encodeMatrix(&out, matrix, keyMap))
+	assert.JSONEq(t, `{"include": [
+		{"name": "Test_A", "suite": "TestWithShardedTestSuite", "shard": 1},
+		{"name": "Test_B", "suite": "TestWithShardedTestSuite"}
+	]}`, out.String())
+
+	// Without a key map the output is the plain matrix
+	keyMap, err = parseKeyMap("")
+	require.NoError(t, err)
+	out.Reset()
+	require.NoError(t, encodeMatrix(&out, matrix, keyMap))
+	expected, err := json.Marshal(matrix)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), out.String())
+
+	// Swapping keys is fine, only the resulting names must be unique
+	_, err = parseKeyMap("test=entrypoint,entrypoint=test")
+	require.NoError(t, err)
+
+	for _, spec := range []string{
+		"test=entrypoint",
+		"test=name,shard=name",
+		"test=name,test=other",
+		"suite=name",
+		"test",
+		"test=",
+	} {
+		_, err := parseKeyMap(spec)
+		require.ErrorIs(t, err, ErrInvalidKeyMap, spec)
+	}
+}
@@ -0,0 +1,19 @@
+package second
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DuplicateTestSuite struct {
+	suite.Suite
+}
+
+func TestWithDuplicateTestSuite(t *testing.T) {
+	suite.Run(t, new(DuplicateTestSuite))
+}
+
+func (s *DuplicateTestSuite) Test_Shared() {}
+
+func (s *DuplicateTestSuite) Test_OnlySecond() {}
@@ -0,0 +1,20 @@
+package fast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FastTestSuite struct {
+	suite.Suite
+}
+
+// testmatrix:timeout=5m
+func TestWithFastTestSuite(t *testing.T) {
+	suite.Run(t, new(FastTestSuite))
+}
+
+func (s *FastTestSuite) Test_A() {}
+
+func (s *FastTestSuite) Test_B() {}
@@ -0,0 +1,20 @@
+package slow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SlowTestSuite struct {
+	suite.Suite
+}
+
+// testmatrix:timeout=1h
+func TestWithSlowTestSuite(t *testing.T) {
+	suite.Run(t, new(SlowTestSuite))
+}
+
+func (s *SlowTestSuite) Test_A() {}
+
+func (s *SlowTestSuite) Test_B() {}
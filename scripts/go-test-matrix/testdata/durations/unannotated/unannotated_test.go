@@ -0,0 +1,19 @@
+package unannotated
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type UnannotatedTestSuite struct {
+	suite.Suite
+}
+
+func TestWithUnannotatedTestSuite(t *testing.T) {
+	suite.Run(t, new(UnannotatedTestSuite))
+}
+
+func (s *UnannotatedTestSuite) Test_A() {}
+
+func (s *UnannotatedTestSuite) Test_B() {}
@@ -0,0 +1,18 @@
+package satisfied
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GoSatisfiedTestSuite struct {
+	suite.Suite
+}
+
+// testmatrix:go=1.21
+func TestWithGoSatisfiedTestSuite(t *testing.T) {
+	suite.Run(t, new(GoSatisfiedTestSuite))
+}
+
+func (s *GoSatisfiedTestSuite) Test_Something() {}
@@ -0,0 +1,17 @@
+package unannotated
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GoUnannotatedTestSuite struct {
+	suite.Suite
+}
+
+func TestWithGoUnannotatedTestSuite(t *testing.T) {
+	suite.Run(t, new(GoUnannotatedTestSuite))
+}
+
+func (s *GoUnannotatedTestSuite) Test_Something() {}
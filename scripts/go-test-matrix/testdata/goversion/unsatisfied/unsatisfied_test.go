@@ -0,0 +1,18 @@
+package unsatisfied
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GoUnsatisfiedTestSuite struct {
+	suite.Suite
+}
+
+// testmatrix:go=1.99.1
+func TestWithGoUnsatisfiedTestSuite(t *testing.T) {
+	suite.Run(t, new(GoUnsatisfiedTestSuite))
+}
+
+func (s *GoUnsatisfiedTestSuite) Test_Something() {}
@@ -0,0 +1,17 @@
+package conforming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConformingTestSuite struct {
+	suite.Suite
+}
+
+func TestWithConformingTestSuite(t *testing.T) {
+	suite.Run(t, new(ConformingTestSuite))
+}
+
+func (s *ConformingTestSuite) Test_A() {}
@@ -0,0 +1,17 @@
+package nonconforming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NonConformingTestSuite struct {
+	suite.Suite
+}
+
+func TestNonConforming(t *testing.T) {
+	suite.Run(t, new(NonConformingTestSuite))
+}
+
+func (s *NonConformingTestSuite) Test_A() {}
@@ -0,0 +1,19 @@
+package inner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type InnerTestSuite struct {
+	suite.Suite
+}
+
+func TestWithInnerTestSuite(t *testing.T) {
+	suite.Run(t, new(InnerTestSuite))
+}
+
+func (s *InnerTestSuite) Test_A() {}
+
+func (s *InnerTestSuite) Test_B() {}
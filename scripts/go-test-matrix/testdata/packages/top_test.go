@@ -0,0 +1,17 @@
+package packages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TopTestSuite struct {
+	suite.Suite
+}
+
+func TestWithTopTestSuite(t *testing.T) {
+	suite.Run(t, new(TopTestSuite))
+}
+
+func (s *TopTestSuite) Test_Top() {}
@@ -0,0 +1,17 @@
+package parseerrors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BrokenTestSuite struct {
+	suite.Suite
+}
+
+func TestWithBrokenTestSuite(t *testing.T) {
+	suite.Run(t, new(BrokenTestSuite)
+}
+
+func (s *BrokenTestSuite) Test_Broken() {}
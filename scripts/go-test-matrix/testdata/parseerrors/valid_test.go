@@ -0,0 +1,17 @@
+package parseerrors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ParseErrorsTestSuite struct {
+	suite.Suite
+}
+
+func TestWithParseErrorsTestSuite(t *testing.T) {
+	suite.Run(t, new(ParseErrorsTestSuite))
+}
+
+func (s *ParseErrorsTestSuite) Test_Valid() {}
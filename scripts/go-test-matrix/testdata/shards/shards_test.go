@@ -0,0 +1,25 @@
+package shards
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ShardsTestSuite struct {
+	suite.Suite
+}
+
+func TestWithShardsTestSuite(t *testing.T) {
+	suite.Run(t, new(ShardsTestSuite))
+}
+
+func (s *ShardsTestSuite) Test_A() {}
+
+func (s *ShardsTestSuite) Test_B() {}
+
+func (s *ShardsTestSuite) Test_C() {}
+
+func (s *ShardsTestSuite) Test_D() {}
+
+func (s *ShardsTestSuite) Test_E() {}
@@ -0,0 +1,36 @@
+package subtests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SubtestTestSuite struct {
+	suite.Suite
+}
+
+func TestWithSubtestTestSuite(t *testing.T) {
+	suite.Run(t, new(SubtestTestSuite))
+}
+
+func (s *SubtestTestSuite) Test_Literal() {
+	s.Require().True(s.Run("first case", func() {
+		s.Run("nested", func() {})
+	}))
+
+	s.T().Run("second_case", func(t *testing.T) {})
+
+	s.Run("v1.2 (legacy)", func() {})
+}
+
+func (s *SubtestTestSuite) Test_Dynamic() {
+	for i := range 2 {
+		s.Run(fmt.Sprintf("case %d", i), func() {})
+	}
+
+	s.Run("literal", func() {})
+}
+
+func (s *SubtestTestSuite) Test_NoSubtests() {}
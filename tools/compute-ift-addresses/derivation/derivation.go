@@ -0,0 +1,95 @@
+// Package derivation implements the address derivations used to pre-compute an IFT deployment:
+// the EVM CREATE address of the IFT contract and the ibc-go GMP interchain account address
+// controlled by it on the counterparty chain.
+package derivation
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// GMPAccountsKey is the module key used by ibc-go's GMP module to derive interchain account addresses.
+	// Formula: SHA256(SHA256("module") + GMPAccountsKey + 0x00 + derivationKey)
+	GMPAccountsKey = "gmp-accounts"
+
+	// lengthPrefixSize is the size of the big-endian length prefix written before each key field
+	lengthPrefixSize = 8
+)
+
+// IFTAddress returns the address of a contract created by deployer at the given nonce.
+func IFTAddress(deployer common.Address, nonce uint64) common.Address {
+	return crypto.CreateAddress(deployer, nonce)
+}
+
+// ICADerivation holds the intermediate values of a GMP account address derivation, useful to find
+// where inputs diverge when an address does not match expectations.
+type ICADerivation struct {
+	// Key is the length-prefixed derivation key, see BuildKey
+	Key []byte
+	// Combined is GMPAccountsKey + 0x00 + Key
+	Combined []byte
+	// ModuleHash is SHA256("module")
+	ModuleHash [32]byte
+	// AddressHash is SHA256(ModuleHash + Combined), the raw account address
+	AddressHash [32]byte
+	// Address is AddressHash encoded with the bech32 prefix
+	Address string
+}
+
+// ICAAddress returns the bech32 encoded GMP account address for the given client ID, sender and salt.
+func ICAAddress(clientID, sender, salt, bech32Prefix string) (string, error) {
+	ica, err := DeriveICA(clientID, sender, salt, bech32Prefix)
+	if err != nil {
+		return "", err
+	}
+	return ica.Address, nil
+}
+
+// DeriveICA derives the GMP account address like ICAAddress and also returns the intermediate values.
+func DeriveICA(clientID, sender, salt, bech32Prefix string) (ICADerivation, error) {
+	key := BuildKey(clientID, sender, salt)
+	combined := append([]byte(GMPAccountsKey), 0x00)
+	combined = append(combined, key...)
+	moduleHash := sha256.Sum256([]byte("module"))
+	finalInput := append(moduleHash[:], combined...)
+	addrHash := sha256.Sum256(finalInput)
+	address, err := bech32.ConvertAndEncode(bech32Prefix, addrHash[:])
+	if err != nil {
+		return ICADerivation{}, err
+	}
+
+	return ICADerivation{
+		Key:         key,
+		Combined:    combined,
+		ModuleHash:  moduleHash,
+		AddressHash: addrHash,
+		Address:     address,
+	}, nil
+}
+
+// BuildKey builds the GMP account derivation key: each of clientID, sender and salt
+// length-prefixed with an 8-byte big-endian length, in that order.
+func BuildKey(clientID, sender, salt string) []byte {
+	clientIDBytes := []byte(clientID)
+	senderBytes := []byte(sender)
+	saltBytes := []byte(salt)
+	size := 3*lengthPrefixSize + len(clientIDBytes) + len(senderBytes) + len(saltBytes)
+	key := make([]byte, 0, size)
+	key = AppendLengthPrefixed(key, clientIDBytes)
+	key = AppendLengthPrefixed(key, senderBytes)
+	key = AppendLengthPrefixed(key, saltBytes)
+	return key
+}
+
+// AppendLengthPrefixed appends the 8-byte big-endian length of data followed by data itself to dst.
+// A Go slice length always fits in a uint64, so the prefix cannot overflow.
+func AppendLengthPrefixed(dst, data []byte) []byte {
+	dst = binary.BigEndian.AppendUint64(dst, uint64(len(data)))
+	dst = append(dst, data...)
+	return dst
+}
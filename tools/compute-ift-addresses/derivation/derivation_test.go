@@ -0,0 +1,178 @@
+package derivation_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/srdtrk/solidity-ibc-eureka/tools/compute-ift-addresses/derivation"
+)
+
+func TestAppendLengthPrefixed(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dst      []byte
+		data     []byte
+		expected []byte
+	}{
+		{
+			name:     "empty data",
+			dst:      nil,
+			data:     nil,
+			expected: []byte{0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:     "non-empty data",
+			dst:      nil,
+			data:     []byte("abc"),
+			expected: []byte{0, 0, 0, 0, 0, 0, 0, 3, 'a', 'b', 'c'},
+		},
+		{
+			name:     "appends to existing dst",
+			dst:      []byte{0xff},
+			data:     []byte{0x01},
+			expected: []byte{0xff, 0, 0, 0, 0, 0, 0, 0, 1, 0x01},
+		},
+		{
+			name:     "length above one byte",
+			dst:      nil,
+			data:     bytes.Repeat([]byte{0xaa}, 0x0102),
+			expected: append([]byte{0, 0, 0, 0, 0, 0, 0x01, 0x02}, bytes.Repeat([]byte{0xaa}, 0x0102)...),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, derivation.AppendLengthPrefixed(tc.dst, tc.data))
+		})
+	}
+}
+
+func TestBuildKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		clientID string
+		sender   string
+		salt     string
+		expected []byte
+	}{
+		{
+			name:     "all fields empty",
+			expected: make([]byte, 24),
+		},
+		{
+			name:     "empty salt",
+			clientID: "08-wasm-0",
+			sender:   "0xab",
+			expected: concat(
+				[]byte{0, 0, 0, 0, 0, 0, 0, 9}, []byte("08-wasm-0"),
+				[]byte{0, 0, 0, 0, 0, 0, 0, 4}, []byte("0xab"),
+				[]byte{0, 0, 0, 0, 0, 0, 0, 0},
+			),
+		},
+		{
+			name:     "all fields set",
+			clientID: "c",
+			sender:   "s",
+			salt:     "salt",
+			expected: concat(
+				[]byte{0, 0, 0, 0, 0, 0, 0, 1}, []byte("c"),
+				[]byte{0, 0, 0, 0, 0, 0, 0, 1}, []byte("s"),
+				[]byte{0, 0, 0, 0, 0, 0, 0, 4}, []byte("salt"),
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, derivation.BuildKey(tc.clientID, tc.sender, tc.salt))
+		})
+	}
+}
+
+func FuzzBuildKey(f *testing.F) {
+	f.Add("", "", "")
+	f.Add("08-wasm-0", "0x68B1D87F95878fE05B998F19b66F4baba5De1aed", "")
+	f.Add("client-0", "sender", "salt")
+
+	f.Fuzz(func(t *testing.T, clientID, sender, salt string) {
+		key := derivation.BuildKey(clientID, sender, salt)
+		require.Len(t, key, 24+len(clientID)+len(sender)+len(salt))
+
+		// Decoding the key must yield the original fields in order and consume it entirely.
+		rest := key
+		for _, field := range []string{clientID, sender, salt} {
+			require.GreaterOrEqual(t, len(rest), 8)
+			length := binary.BigEndian.Uint64(rest[:8])
+			require.Equal(t, uint64(len(field)), length)
+			require.Equal(t, field, string(rest[8:8+length]))
+			rest = rest[8+length:]
+		}
+		require.Empty(t, rest)
+	})
+}
+
+func TestIFTAndICAAddress(t *testing.T) {
+	deployer := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+	iftAddress := derivation.IFTAddress(deployer, 18)
+	require.Equal(t, "0x68B1D87F95878fE05B998F19b66F4baba5De1aed", iftAddress.Hex())
+
+	icaAddress, err := derivation.ICAAddress("08-wasm-0", iftAddress.Hex(), "", "wf")
+	require.NoError(t, err)
+	require.Equal(t, "wf1gjj772jd2hf20dswpnwrkfetmpkndak8w5smud99dtzwrynrut0qnetxqc", icaAddress)
+}
+
+func TestDeriveICA(t *testing.T) {
+	ica, err := derivation.DeriveICA("08-wasm-0", "0x68B1D87F95878fE05B998F19b66F4baba5De1aed", "", "wf")
+	require.NoError(t, err)
+
+	require.Equal(t, derivation.BuildKey("08-wasm-0", "0x68B1D87F95878fE05B998F19b66F4baba5De1aed", ""), ica.Key)
+	require.Equal(t, concat([]byte(derivation.GMPAccountsKey), []byte{0x00}, ica.Key), ica.Combined)
+	require.Equal(t, sha256.Sum256([]byte("module")), ica.ModuleHash)
+	require.Equal(t, sha256.Sum256(concat(ica.ModuleHash[:], ica.Combined)), ica.AddressHash)
+	require.Equal(t, "wf1gjj772jd2hf20dswpnwrkfetmpkndak8w5smud99dtzwrynrut0qnetxqc", ica.Address)
+}
+
+func concat(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+func TestSolanaGMPAccountPDA(t *testing.T) {
+	// Expected values are those of solana.Ics27Gmp.GmpAccountPDA in the e2e module for the ICS27 GMP program
+	const programID = "3W3h4WSE8J9vFzVN8TGFGc9Uchbry3M4MBz4icdSWcFi"
+	const sender = "0x68B1D87F95878fE05B998F19b66F4baba5De1aed"
+
+	testCases := []struct {
+		name     string
+		salt     string
+		expected derivation.SolanaGMPAccount
+	}{
+		{
+			name:     "empty salt",
+			expected: derivation.SolanaGMPAccount{Address: "E2wzuKASFLYJwUWx8zAebyUjMAx65krDcEWW99iUjfmK", Bump: 255},
+		},
+		{
+			name:     "salt needing a second bump",
+			salt:     "mysalt",
+			expected: derivation.SolanaGMPAccount{Address: "8v2RPnbw8V4dxyHS7oj1iSJwKKV5SryNpiubtVDgFxMJ", Bump: 254},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			account, err := derivation.SolanaGMPAccountPDA(programID, "08-wasm-0", sender, tc.salt)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, account)
+		})
+	}
+
+	for _, invalid := range []string{"", "not-base58!", "3W3h4WSE8J9vFzVN8TGFGc9Uchbry3M4MBz4icdSW"} {
+		_, err := derivation.SolanaGMPAccountPDA(invalid, "08-wasm-0", sender, "")
+		require.ErrorIs(t, err, derivation.ErrInvalidSolanaProgramID, invalid)
+	}
+}
@@ -0,0 +1,81 @@
+package derivation
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"github.com/cosmos/btcutil/base58"
+)
+
+const (
+	// SolanaGMPAccountSeed is the const seed of the ICS27 GMP program's account PDAs.
+	// Seeds: ["gmp_account", SHA256(borsh(AccountIdentifier))]
+	SolanaGMPAccountSeed = "gmp_account"
+
+	// solanaPDAMarker is appended to the seeds of a program derived address before hashing
+	solanaPDAMarker = "ProgramDerivedAddress"
+	// solanaPublicKeySize is the size of a Solana public key
+	solanaPublicKeySize = 32
+)
+
+// ErrInvalidSolanaProgramID is returned for a program ID that is not a base58 encoded public key.
+var ErrInvalidSolanaProgramID = errors.New("invalid Solana program ID")
+
+// errNoViableBump is returned when every bump seed yields a point on the curve, which is practically impossible.
+var errNoViableBump = errors.New("unable to find a viable program address bump seed")
+
+// SolanaGMPAccount is the GMP account PDA controlled by a sender on Solana, the counterpart of the ICA on a
+// Cosmos chain.
+type SolanaGMPAccount struct {
+	// Address is the base58 encoded PDA
+	Address string
+	// Bump is the bump seed FindProgramAddress settled on
+	Bump uint8
+}
+
+// SolanaGMPAccountPDA derives the GMP account PDA of the ICS27 GMP program for the given client ID, sender and
+// salt. It mirrors solana.Ics27Gmp.GmpAccountPDA of the e2e module: the account identifier is Borsh serialized
+// as client_id (string), sender (string) and salt (Vec<u8>), each prefixed with its u32 little-endian length.
+func SolanaGMPAccountPDA(programID, clientID, sender, salt string) (SolanaGMPAccount, error) {
+	program := base58.Decode(programID)
+	if len(program) != solanaPublicKeySize {
+		return SolanaGMPAccount{}, fmt.Errorf("%w %q: expected %d bytes, got %d", ErrInvalidSolanaProgramID, programID, solanaPublicKeySize, len(program))
+	}
+
+	var accountID []byte
+	for _, field := range []string{clientID, sender, salt} {
+		accountID = binary.LittleEndian.AppendUint32(accountID, uint32(len(field)))
+		accountID = append(accountID, field...)
+	}
+	accountIDHash := sha256.Sum256(accountID)
+
+	address, bump, err := findProgramAddress([][]byte{[]byte(SolanaGMPAccountSeed), accountIDHash[:]}, program)
+	if err != nil {
+		return SolanaGMPAccount{}, err
+	}
+	return SolanaGMPAccount{Address: base58.Encode(address[:]), Bump: bump}, nil
+}
+
+// findProgramAddress returns the first address off the ed25519 curve derived from the seeds and a bump seed,
+// trying bumps from 255 down like Solana's find_program_address.
+func findProgramAddress(seeds [][]byte, programID []byte) ([sha256.Size]byte, uint8, error) {
+	for bump := 255; bump >= 0; bump-- {
+		hasher := sha256.New()
+		for _, seed := range seeds {
+			hasher.Write(seed)
+		}
+		hasher.Write([]byte{byte(bump)})
+		hasher.Write(programID)
+		hasher.Write([]byte(solanaPDAMarker))
+
+		var address [sha256.Size]byte
+		hasher.Sum(address[:0])
+		if _, err := new(edwards25519.Point).SetBytes(address[:]); err != nil {
+			return address, uint8(bump), nil
+		}
+	}
+	return [sha256.Size]byte{}, 0, errNoViableBump
+}
@@ -1,90 +1,550 @@
 package main
 
 import (
-	"crypto/sha256"
-	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"text/tabwriter"
 
-	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/srdtrk/solidity-ibc-eureka/tools/compute-ift-addresses/derivation"
+)
+
+var (
+	errUsage                = errors.New("invalid usage")
+	errUnknownAddressFormat = errors.New("unknown address format")
+	errDebugWithSalts       = errors.New("--debug cannot be combined with --salts")
+	errSolanaWithSalts      = errors.New("--solana-gmp-program cannot be combined with --salts")
+	errMissingFlags         = errors.New("missing required flags")
+	errNonceTooLarge        = errors.New("nonce too large")
+	errUnknownNetwork       = errors.New("unknown network")
+	errPositionalWithFlags  = errors.New("positional arguments cannot be combined with --private-key, --private-key-file, --nonce, --client-id, --bech32-prefix, --network or --salt")
 )
 
+// networkBech32Prefixes maps the names accepted by --network to the bech32 prefix of the chain
+var networkBech32Prefixes = map[string]string{
+	"akash":     "akash",
+	"celestia":  "celestia",
+	"cosmoshub": "cosmos",
+	"dydx":      "dydx",
+	"injective": "inj",
+	"juno":      "juno",
+	"neutron":   "neutron",
+	"noble":     "noble",
+	"osmosis":   "osmo",
+	"stargaze":  "stars",
+	"stride":    "stride",
+	"wfchain":   "wf",
+}
+
+// Supported values of the --address-format flag
 const (
-	// gmpAccountsKey is the module key used by ibc-go's GMP module to derive interchain account addresses.
-	// Formula: SHA256(SHA256("module") + gmpAccountsKey + 0x00 + derivationKey)
-	gmpAccountsKey = "gmp-accounts"
+	addressFormatChecksum = "checksum"
+	addressFormatLower    = "lower"
+	addressFormatRaw      = "raw"
 )
 
+// maxNonce is the highest deployer nonce accepted without --force. Deployers rarely send anywhere near
+// this many transactions, so a larger value is most likely a mistyped nonce.
+const maxNonce = 1_000_000
+
+// Names of the flags holding the derivation inputs
+const (
+	flagPrivateKey     = "private-key"
+	flagPrivateKeyFile = "private-key-file"
+	flagNonce          = "nonce"
+	flagClientID       = "client-id"
+	flagBech32Prefix   = "bech32-prefix"
+	flagNetwork        = "network"
+	flagSalt           = "salt"
+)
+
+// privateKeyEnv is the environment variable the private key is read from when no key flag is passed
+const privateKeyEnv = "PRIVATE_KEY"
+
+// config holds the values of the persistent flags shared by all subcommands
+type config struct {
+	privateKeyHex  string
+	privateKeyFile string
+	nonce          uint64
+	clientID       string
+	bech32Prefix   string
+	network        string
+	salt           string
+	// solanaGMPProgram is the ICS27 GMP program ID whose GMP account PDA is printed next to the ICA
+	solanaGMPProgram string
+
+	salts         []string
+	prefixMatch   string
+	addressFormat string
+	jsonOutput    bool
+	debug         bool
+	force         bool
+}
+
+// saltResult is a single row of the salt table
+type saltResult struct {
+	Salt       string `json:"salt"`
+	ICAAddress string `json:"icaAddress"`
+}
+
+// output is the JSON document printed with --json
+type output struct {
+	IFTAddress string       `json:"iftAddress,omitempty"`
+	ICAAddress string       `json:"icaAddress,omitempty"`
+	Salts      []saltResult `json:"salts,omitempty"`
+	Context    *icaContext  `json:"context,omitempty"`
+	Debug      *debugOutput `json:"debug,omitempty"`
+	// SolanaGMPAccount is the Solana counterpart of the ICA, only set with --solana-gmp-program
+	SolanaGMPAccount *solanaGMPAccount `json:"solanaGmpAccount,omitempty"`
+}
+
+// icaContext echoes the GMP inputs the ICA address was derived from, so the output is self-documenting
+type icaContext struct {
+	ClientID string `json:"clientId"`
+	Sender   string `json:"sender"`
+	// Salt is unset for salt tables, where every row carries its own salt
+	Salt         *string `json:"salt,omitempty"`
+	Bech32Prefix string  `json:"bech32Prefix"`
+}
+
+// solanaGMPAccount is the GMP account PDA the IFT controls on Solana, printed with --solana-gmp-program
+type solanaGMPAccount struct {
+	ProgramID string `json:"programId"`
+	Address   string `json:"address"`
+	Bump      uint8  `json:"bump"`
+}
+
+// debugOutput holds the hex encoded intermediate values of the ICA derivation printed with --debug
+type debugOutput struct {
+	Key         string `json:"key"`
+	Combined    string `json:"combined"`
+	ModuleHash  string `json:"moduleHash"`
+	AddressHash string `json:"addressHash"`
+}
+
+func newDebugOutput(d derivation.ICADerivation) *debugOutput {
+	return &debugOutput{
+		Key:         hexutil.Encode(d.Key),
+		Combined:    hexutil.Encode(d.Combined),
+		ModuleHash:  hexutil.Encode(d.ModuleHash[:]),
+		AddressHash: hexutil.Encode(d.AddressHash[:]),
+	}
+}
+
 func main() {
-	if len(os.Args) < 5 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <private-key-hex> <nonce> <client-id> <bech32-prefix> [salt]\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "Example: %s ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80 18 08-wasm-0 wf\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "\nComputes the IFT contract address and its corresponding ICA address.\n")
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
 		os.Exit(1)
 	}
+}
 
-	privateKeyHex := os.Args[1]
-	nonce, err := strconv.ParseUint(os.Args[2], 10, 64)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing nonce: %v\n", err)
-		os.Exit(1)
+func run(args []string, stdout, stderr io.Writer) error {
+	cmd := newRootCmd()
+	cmd.SetArgs(args)
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	return cmd.Execute()
+}
+
+func newRootCmd() *cobra.Command {
+	cfg := &config{}
+
+	rootCmd := &cobra.Command{
+		Use:   "compute-ift-addresses",
+		Short: "Computes the IFT contract address and its corresponding ICA address",
+		Long: `compute-ift-addresses computes the address of an IFT contract deployed by the given key at the
+given nonce, and the GMP interchain account it controls on the counterparty chain.
+
+The positional form "compute-ift-addresses <private-key-hex> <nonce> <client-id> <bech32-prefix> [salt]"
+is deprecated, as it leaks the private key into shell history, and behaves like the both subcommand.
+Prefer --private-key-file or the PRIVATE_KEY environment variable.`,
+		Example: `  compute-ift-addresses both --private-key-file deployer.key --nonce 18 --client-id 08-wasm-0 --bech32-prefix wf
+  PRIVATE_KEY=ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80 compute-ift-addresses ift --nonce 18`,
+		Args:              cobra.ArbitraryArgs,
+		SilenceErrors:     true,
+		SilenceUsage:      true,
+		CompletionOptions: cobra.CompletionOptions{DisableDefaultCmd: true},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 4 || len(args) > 5 {
+				_ = cmd.Usage()
+				return errUsage
+			}
+			for _, name := range []string{flagPrivateKey, flagPrivateKeyFile, flagNonce, flagClientID, flagBech32Prefix, flagNetwork, flagSalt} {
+				if cmd.Flags().Changed(name) {
+					return errPositionalWithFlags
+				}
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: the positional form is deprecated as it leaks the private key into shell history and process listings, use --%s or %s with the both subcommand\n", flagPrivateKeyFile, privateKeyEnv)
+
+			cfg.privateKeyHex = args[0]
+			nonce, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("parsing nonce: %w", err)
+			}
+			cfg.nonce = nonce
+			cfg.clientID = args[2]
+			cfg.bech32Prefix = args[3]
+			if len(args) > 4 {
+				cfg.salt = args[4]
+			}
+
+			return cfg.runICA(cmd.OutOrStdout(), cmd.ErrOrStderr(), true)
+		},
+	}
+
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&cfg.privateKeyHex, flagPrivateKey, "", fmt.Sprintf("Hex encoded private key of the IFT deployer, read from $%s when no key flag is set", privateKeyEnv))
+	flags.StringVar(&cfg.privateKeyFile, flagPrivateKeyFile, "", "File holding the hex encoded private key of the IFT deployer")
+	flags.Uint64Var(&cfg.nonce, flagNonce, 0, "Nonce of the deployer at which the IFT contract is created")
+	flags.StringVar(&cfg.clientID, flagClientID, "", "Client ID of the counterparty on the chain hosting the ICA")
+	flags.StringVar(&cfg.bech32Prefix, flagBech32Prefix, "", "Bech32 prefix of the chain hosting the ICA")
+	flags.StringVar(&cfg.network, flagNetwork, "", fmt.Sprintf("Known network whose bech32 prefix to use instead of --bech32-prefix, one of %s", strings.Join(supportedNetworks(), ", ")))
+	flags.StringVar(&cfg.salt, flagSalt, "", "Salt of the ICA")
+	flags.StringVar(&cfg.solanaGMPProgram, "solana-gmp-program", "", "ICS27 GMP program ID on Solana; also print the GMP account PDA the IFT controls there, derived from the same client ID, sender and salt")
+	flags.StringArrayVar(&cfg.salts, "salts", nil, "Salt to compute the ICA address for; repeat to print a table of several salts")
+	flags.StringVar(&cfg.prefixMatch, "prefix-match", "", "Only list salts whose ICA address starts with this string after the bech32 prefix")
+	flags.StringVar(&cfg.addressFormat, "address-format", addressFormatChecksum, "Format of the printed IFT address: checksum, lower or raw (lowercase without 0x)")
+	flags.BoolVar(&cfg.jsonOutput, "json", false, "Print the result as JSON")
+	flags.BoolVar(&cfg.debug, "debug", false, "Also print the intermediate values of the ICA derivation")
+	flags.BoolVar(&cfg.force, "force", false, fmt.Sprintf("Accept nonces above %d and do not warn about nonce 0", maxNonce))
+
+	rootCmd.MarkFlagsMutuallyExclusive(flagPrivateKey, flagPrivateKeyFile)
+
+	rootCmd.AddCommand(
+		&cobra.Command{
+			Use:   "ift",
+			Short: "Computes the IFT contract address",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				if err := cfg.requireKeyFlags(cmd); err != nil {
+					return err
+				}
+				return cfg.runIFT(cmd.OutOrStdout(), cmd.ErrOrStderr())
+			},
+		},
+		&cobra.Command{
+			Use:   "ica",
+			Short: "Computes the ICA address controlled by the IFT contract",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				if err := cfg.requireICAFlags(cmd); err != nil {
+					return err
+				}
+				return cfg.runICA(cmd.OutOrStdout(), cmd.ErrOrStderr(), false)
+			},
+		},
+		&cobra.Command{
+			Use:   "both",
+			Short: "Computes the IFT contract address and the ICA address it controls",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				if err := cfg.requireICAFlags(cmd); err != nil {
+					return err
+				}
+				return cfg.runICA(cmd.OutOrStdout(), cmd.ErrOrStderr(), true)
+			},
+		},
+		&cobra.Command{
+			Use:   "selftest",
+			Short: "Checks the IFT and ICA derivations against embedded known-answer vectors",
+			Long: `selftest derives the IFT and ICA address of each embedded known-answer vector and exits non-zero if
+any of them differs from the expected address, guarding against accidental changes to the derivations.`,
+			Args: cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				vectors, err := loadSelfTestVectors()
+				if err != nil {
+					return err
+				}
+				return runSelfTest(cmd.OutOrStdout(), vectors)
+			},
+		},
+	)
+
+	return rootCmd
+}
+
+// requireFlags returns an error listing the given flags that were not set on the command line
+func requireFlags(cmd *cobra.Command, names ...string) error {
+	var missing []string
+	for _, name := range names {
+		if !cmd.Flags().Changed(name) {
+			missing = append(missing, "--"+name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", errMissingFlags, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// requireKeyFlags checks that the deployer key and nonce are set, loading the key from --private-key-file or
+// the PRIVATE_KEY environment variable when --private-key is not passed
+func (c *config) requireKeyFlags(cmd *cobra.Command) error {
+	switch {
+	case cmd.Flags().Changed(flagPrivateKey):
+	case cmd.Flags().Changed(flagPrivateKeyFile):
+		bz, err := os.ReadFile(c.privateKeyFile)
+		if err != nil {
+			return fmt.Errorf("reading private key file: %w", err)
+		}
+		c.privateKeyHex = strings.TrimSpace(string(bz))
+		clear(bz)
+	case os.Getenv(privateKeyEnv) != "":
+		c.privateKeyHex = os.Getenv(privateKeyEnv)
+	default:
+		return fmt.Errorf("%w: --%s (or --%s, %s)", errMissingFlags, flagPrivateKey, flagPrivateKeyFile, privateKeyEnv)
+	}
+
+	return requireFlags(cmd, flagNonce)
+}
+
+// requireICAFlags checks that the flags the ICA derivation needs are set, taking the bech32 prefix from
+// --network unless --bech32-prefix overrides it
+func (c *config) requireICAFlags(cmd *cobra.Command) error {
+	if err := c.requireKeyFlags(cmd); err != nil {
+		return err
+	}
+
+	if c.network != "" {
+		prefix, ok := networkBech32Prefixes[c.network]
+		if !ok {
+			return fmt.Errorf("%w %q, expected one of %s", errUnknownNetwork, c.network, strings.Join(supportedNetworks(), ", "))
+		}
+		if !cmd.Flags().Changed(flagBech32Prefix) {
+			c.bech32Prefix = prefix
+		}
+		return requireFlags(cmd, flagClientID)
+	}
+
+	if err := requireFlags(cmd, flagClientID, flagBech32Prefix); err != nil {
+		return fmt.Errorf("%w (or --%s)", err, flagNetwork)
+	}
+	return nil
+}
+
+// supportedNetworks returns the names accepted by --network in sorted order
+func supportedNetworks() []string {
+	return slices.Sorted(maps.Keys(networkBech32Prefixes))
+}
+
+// checkNonce rejects nonces above maxNonce and warns on stderr about nonce 0, unless --force is set
+func (c *config) checkNonce(stderr io.Writer) error {
+	if c.force {
+		return nil
+	}
+	if c.nonce > maxNonce {
+		return fmt.Errorf("%w: %d is above %d, pass --force if this is intended", errNonceTooLarge, c.nonce, maxNonce)
+	}
+	if c.nonce == 0 {
+		fmt.Fprintln(stderr, "Warning: nonce 0 is the deployer's first transaction, make sure the IFT is its first deployment")
 	}
-	clientID := os.Args[3]
-	bech32Prefix := os.Args[4]
-	salt := ""
-	if len(os.Args) > 5 {
-		salt = os.Args[5]
+	return nil
+}
+
+// iftAddress computes the IFT address from the deployer private key and nonce
+func (c *config) iftAddress(stderr io.Writer) (common.Address, error) {
+	if err := c.checkNonce(stderr); err != nil {
+		return common.Address{}, err
 	}
 
-	// Compute IFT address from private key + nonce
-	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	// Decoded by hand rather than with crypto.HexToECDSA so the key bytes can be zeroed after use
+	keyBytes, err := hex.DecodeString(strings.TrimPrefix(c.privateKeyHex, "0x"))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing private key: %v\n", err)
-		os.Exit(1)
+		return common.Address{}, fmt.Errorf("parsing private key: %w", err)
 	}
+	defer clear(keyBytes)
+
+	privateKey, err := crypto.ToECDSA(keyBytes)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("parsing private key: %w", err)
+	}
+	defer clear(privateKey.D.Bits())
+
 	deployer := crypto.PubkeyToAddress(privateKey.PublicKey)
-	iftAddress := crypto.CreateAddress(deployer, nonce)
+	return derivation.IFTAddress(deployer, c.nonce), nil
+}
+
+func (c *config) runIFT(w, stderr io.Writer) error {
+	iftAddress, err := c.iftAddress(stderr)
+	if err != nil {
+		return err
+	}
+	formattedIFTAddress, err := formatAddress(iftAddress, c.addressFormat)
+	if err != nil {
+		return err
+	}
+
+	if c.jsonOutput {
+		return writeJSON(w, output{IFTAddress: formattedIFTAddress})
+	}
+
+	fmt.Fprintf(w, "IFT Address: %s\n", formattedIFTAddress)
+	return nil
+}
+
+// runICA computes the ICA address, or the salt table when --salts is set, also printing the IFT
+// address when includeIFT is true
+func (c *config) runICA(w, stderr io.Writer, includeIFT bool) error {
+	iftAddress, err := c.iftAddress(stderr)
+	if err != nil {
+		return err
+	}
+
+	// The format only affects what is printed, the ICA is always derived from the checksummed sender
+	formattedIFTAddress, err := formatAddress(iftAddress, c.addressFormat)
+	if err != nil {
+		return err
+	}
+	if !includeIFT {
+		formattedIFTAddress = ""
+	}
+
+	context := &icaContext{ClientID: c.clientID, Sender: iftAddress.Hex(), Bech32Prefix: c.bech32Prefix}
+
+	var solanaAccount *solanaGMPAccount
+	if c.solanaGMPProgram != "" {
+		if len(c.salts) > 0 {
+			return errSolanaWithSalts
+		}
+		account, err := derivation.SolanaGMPAccountPDA(c.solanaGMPProgram, c.clientID, iftAddress.Hex(), c.salt)
+		if err != nil {
+			return fmt.Errorf("computing Solana GMP account: %w", err)
+		}
+		solanaAccount = &solanaGMPAccount{ProgramID: c.solanaGMPProgram, Address: account.Address, Bump: account.Bump}
+	}
+
+	if len(c.salts) > 0 {
+		if c.debug {
+			return errDebugWithSalts
+		}
+
+		results, err := computeSaltTable(c.clientID, iftAddress.Hex(), c.bech32Prefix, c.salts, c.prefixMatch)
+		if err != nil {
+			return err
+		}
+
+		if c.jsonOutput {
+			return writeJSON(w, output{IFTAddress: formattedIFTAddress, Salts: results, Context: context})
+		}
+
+		if includeIFT {
+			fmt.Fprintf(w, "IFT Address: %s\n\n", formattedIFTAddress)
+		}
+		writeContext(w, context)
+		fmt.Fprintln(w)
+		return writeSaltTable(w, results)
+	}
 
 	// Compute ICA address from client ID + IFT address + salt
-	icaAddress, err := computeICAAddress(clientID, iftAddress.Hex(), salt, bech32Prefix)
+	ica, err := derivation.DeriveICA(c.clientID, iftAddress.Hex(), c.salt, c.bech32Prefix)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error computing ICA address: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("computing ICA address: %w", err)
+	}
+
+	context.Salt = &c.salt
+	result := output{IFTAddress: formattedIFTAddress, ICAAddress: ica.Address, SolanaGMPAccount: solanaAccount, Context: context}
+	if c.debug {
+		result.Debug = newDebugOutput(ica)
+	}
+
+	if c.jsonOutput {
+		return writeJSON(w, result)
+	}
+
+	if includeIFT {
+		fmt.Fprintf(w, "IFT Address: %s\n", result.IFTAddress)
 	}
+	fmt.Fprintf(w, "ICA Address: %s\n", result.ICAAddress)
+	writeSolanaGMPAccount(w, result.SolanaGMPAccount)
+	fmt.Fprintln(w)
+	writeContext(w, result.Context)
+	if result.Debug != nil {
+		fmt.Fprintf(w, "\nKey:          %s\n", result.Debug.Key)
+		fmt.Fprintf(w, "Combined:     %s\n", result.Debug.Combined)
+		fmt.Fprintf(w, "Module Hash:  %s\n", result.Debug.ModuleHash)
+		fmt.Fprintf(w, "Address Hash: %s\n", result.Debug.AddressHash)
+	}
+	return nil
+}
+
+// formatAddress renders an EVM address as the EIP-55 checksummed hex, lowercase hex, or lowercase hex without 0x
+func formatAddress(address common.Address, format string) (string, error) {
+	switch format {
+	case addressFormatChecksum:
+		return address.Hex(), nil
+	case addressFormatLower:
+		return strings.ToLower(address.Hex()), nil
+	case addressFormatRaw:
+		return strings.ToLower(strings.TrimPrefix(address.Hex(), "0x")), nil
+	default:
+		return "", fmt.Errorf("%w %q, expected one of %s, %s, %s", errUnknownAddressFormat, format, addressFormatChecksum, addressFormatLower, addressFormatRaw)
+	}
+}
+
+// computeSaltTable derives the ICA address for each salt, keeping only those whose bech32 data part
+// starts with prefixMatch when it is set.
+func computeSaltTable(clientID, sender, bech32Prefix string, salts []string, prefixMatch string) ([]saltResult, error) {
+	var results []saltResult
+	for _, salt := range salts {
+		icaAddress, err := derivation.ICAAddress(clientID, sender, salt, bech32Prefix)
+		if err != nil {
+			return nil, fmt.Errorf("computing ICA address for salt %q: %w", salt, err)
+		}
+
+		// The data part follows the "1" separator after the human readable prefix
+		dataPart := strings.TrimPrefix(icaAddress, bech32Prefix+"1")
+		if prefixMatch != "" && !strings.HasPrefix(dataPart, prefixMatch) {
+			continue
+		}
 
-	fmt.Printf("IFT Address: %s\n", iftAddress.Hex())
-	fmt.Printf("ICA Address: %s\n", icaAddress)
+		results = append(results, saltResult{Salt: salt, ICAAddress: icaAddress})
+	}
+
+	return results, nil
 }
 
-func computeICAAddress(clientID, sender, salt, bech32Prefix string) (string, error) {
-	key := buildKey(clientID, sender, salt)
-	combined := append([]byte(gmpAccountsKey), 0x00)
-	combined = append(combined, key...)
-	moduleHash := sha256.Sum256([]byte("module"))
-	finalInput := append(moduleHash[:], combined...)
-	addrHash := sha256.Sum256(finalInput)
-	addr := addrHash[:]
-	return bech32.ConvertAndEncode(bech32Prefix, addr)
+func writeContext(w io.Writer, context *icaContext) {
+	fmt.Fprintf(w, "Client ID:     %s\n", context.ClientID)
+	fmt.Fprintf(w, "Sender:        %s\n", context.Sender)
+	if context.Salt != nil {
+		fmt.Fprintf(w, "Salt:          %q\n", *context.Salt)
+	}
+	fmt.Fprintf(w, "Bech32 Prefix: %s\n", context.Bech32Prefix)
 }
 
-func buildKey(clientID, sender, salt string) []byte {
-	clientIDBytes := []byte(clientID)
-	senderBytes := []byte(sender)
-	saltBytes := []byte(salt)
-	size := 8 + len(clientIDBytes) + 8 + len(senderBytes) + 8 + len(saltBytes)
-	key := make([]byte, 0, size)
-	key = appendLengthPrefixed(key, clientIDBytes)
-	key = appendLengthPrefixed(key, senderBytes)
-	key = appendLengthPrefixed(key, saltBytes)
-	return key
+// writeSolanaGMPAccount prints the Solana GMP account line, if one was derived
+func writeSolanaGMPAccount(w io.Writer, account *solanaGMPAccount) {
+	if account == nil {
+		return
+	}
+	fmt.Fprintf(w, "Solana GMP Account: %s (program %s, bump %d)\n", account.Address, account.ProgramID, account.Bump)
+}
+
+func writeSaltTable(w io.Writer, results []saltResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SALT\tICA ADDRESS")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%q\t%s\n", result.Salt, result.ICAAddress)
+	}
+	return tw.Flush()
 }
 
-func appendLengthPrefixed(dst, data []byte) []byte {
-	lenBuf := make([]byte, 8)
-	binary.BigEndian.PutUint64(lenBuf, uint64(len(data)))
-	dst = append(dst, lenBuf...)
-	dst = append(dst, data...)
-	return dst
+func writeJSON(w io.Writer, v output) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
 }
@@ -0,0 +1,427 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/srdtrk/solidity-ibc-eureka/tools/compute-ift-addresses/derivation"
+)
+
+const (
+	testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	testIFTAddress = "0x68B1D87F95878fE05B998F19b66F4baba5De1aed"
+
+	// testContext is the text block echoing the derivation inputs of the default test arguments
+	testContext = "\nClient ID:     08-wasm-0\nSender:        " + testIFTAddress + "\nSalt:          \"\"\nBech32 Prefix: wf\n"
+)
+
+func testICAContext(salt string) *icaContext {
+	return &icaContext{ClientID: "08-wasm-0", Sender: testIFTAddress, Salt: &salt, Bech32Prefix: "wf"}
+}
+
+func runCLI(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	err := run(args, &stdout, &stderr)
+	return stdout.String(), err
+}
+
+func TestRunSingleSalt(t *testing.T) {
+	out, err := runCLI(t, testPrivateKey, "18", "08-wasm-0", "wf")
+	require.NoError(t, err)
+	require.Equal(t, "IFT Address: "+testIFTAddress+"\nICA Address: wf1gjj772jd2hf20dswpnwrkfetmpkndak8w5smud99dtzwrynrut0qnetxqc\n"+testContext, out)
+}
+
+func TestRunMultipleSalts(t *testing.T) {
+	out, err := runCLI(t, "--salts", "", "--salts", "mysalt", testPrivateKey, "18", "08-wasm-0", "wf")
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 9)
+	require.Equal(t, "IFT Address: "+testIFTAddress, lines[0])
+	require.Equal(t, []string{"Client ID:     08-wasm-0", "Sender:        " + testIFTAddress, "Bech32 Prefix: wf"}, lines[2:5])
+	require.Equal(t, []string{"SALT", "ICA", "ADDRESS"}, strings.Fields(lines[6]))
+	require.Equal(t, []string{`""`, "wf1gjj772jd2hf20dswpnwrkfetmpkndak8w5smud99dtzwrynrut0qnetxqc"}, strings.Fields(lines[7]))
+	require.Equal(t, []string{`"mysalt"`, "wf1ap6hg2kdxrrrar5d9qgnccdachk88kuf0z68hh042trjkrmpagjq4xsfee"}, strings.Fields(lines[8]))
+}
+
+func TestComputeSaltTablePrefixMatch(t *testing.T) {
+	salts := []string{"", "mysalt"}
+
+	results, err := computeSaltTable("08-wasm-0", testIFTAddress, "wf", salts, "ap6hg")
+	require.NoError(t, err)
+	require.Equal(t, []saltResult{{Salt: "mysalt", ICAAddress: "wf1ap6hg2kdxrrrar5d9qgnccdachk88kuf0z68hh042trjkrmpagjq4xsfee"}}, results)
+
+	// Only the start of the data part is matched, not a substring anywhere in it
+	results, err = computeSaltTable("08-wasm-0", testIFTAddress, "wf", salts, "2kdx")
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	results, err = computeSaltTable("08-wasm-0", testIFTAddress, "wf", salts, "gjj7")
+	require.NoError(t, err)
+	require.Equal(t, []saltResult{{Salt: "", ICAAddress: "wf1gjj772jd2hf20dswpnwrkfetmpkndak8w5smud99dtzwrynrut0qnetxqc"}}, results)
+
+	// The bech32 prefix itself is not part of the match
+	results, err = computeSaltTable("08-wasm-0", testIFTAddress, "wf", salts, "wf1")
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	results, err = computeSaltTable("08-wasm-0", testIFTAddress, "wf", salts, "")
+	require.NoError(t, err)
+	require.Len(t, results, len(salts))
+	for i, result := range results {
+		expected, err := derivation.ICAAddress("08-wasm-0", testIFTAddress, salts[i], "wf")
+		require.NoError(t, err)
+		require.Equal(t, expected, result.ICAAddress)
+	}
+}
+
+func TestRunAddressFormat(t *testing.T) {
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{format: "checksum", expected: testIFTAddress},
+		{format: "lower", expected: "0x68b1d87f95878fe05b998f19b66f4baba5de1aed"},
+		{format: "raw", expected: "68b1d87f95878fe05b998f19b66f4baba5de1aed"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			out, err := runCLI(t, "--address-format", tc.format, testPrivateKey, "18", "08-wasm-0", "wf")
+			require.NoError(t, err)
+			// The ICA address is derived from the checksummed sender regardless of the printed format
+			require.Equal(t, "IFT Address: "+tc.expected+"\nICA Address: wf1gjj772jd2hf20dswpnwrkfetmpkndak8w5smud99dtzwrynrut0qnetxqc\n"+testContext, out)
+
+			out, err = runCLI(t, "--json", "--address-format", tc.format, testPrivateKey, "18", "08-wasm-0", "wf")
+			require.NoError(t, err)
+			var single output
+			require.NoError(t, json.Unmarshal([]byte(out), &single))
+			require.Equal(t, output{IFTAddress: tc.expected, ICAAddress: "wf1gjj772jd2hf20dswpnwrkfetmpkndak8w5smud99dtzwrynrut0qnetxqc", Context: testICAContext("")}, single)
+
+			out, err = runCLI(t, "--json", "--address-format", tc.format, "--salts", "mysalt", testPrivateKey, "18", "08-wasm-0", "wf")
+			require.NoError(t, err)
+			var table output
+			require.NoError(t, json.Unmarshal([]byte(out), &table))
+			require.Equal(t, tc.expected, table.IFTAddress)
+			require.Equal(t, []saltResult{{Salt: "mysalt", ICAAddress: "wf1ap6hg2kdxrrrar5d9qgnccdachk88kuf0z68hh042trjkrmpagjq4xsfee"}}, table.Salts)
+		})
+	}
+
+	_, err := runCLI(t, "--address-format", "upper", testPrivateKey, "18", "08-wasm-0", "wf")
+	require.ErrorIs(t, err, errUnknownAddressFormat)
+}
+
+func TestRunDebug(t *testing.T) {
+	const (
+		expectedKey         = "0x000000000000000930382d7761736d2d30000000000000002a3078363842314438374639353837386645303542393938463139623636463462616261354465316165640000000000000000"
+		expectedCombined    = "0x676d702d6163636f756e747300" + "000000000000000930382d7761736d2d30000000000000002a3078363842314438374639353837386645303542393938463139623636463462616261354465316165640000000000000000"
+		expectedModuleHash  = "0x120970d812836f19888625587a4606a5ad23cef31c8684e601771552548fc6b9"
+		expectedAddressHash = "0x44a5ef2a4d55d2a7b60e0cdc3b272bd86d36f6c77521be34a56ac4e19263e2de"
+	)
+
+	out, err := runCLI(t, "--debug", testPrivateKey, "18", "08-wasm-0", "wf")
+	require.NoError(t, err)
+	require.Contains(t, out, "ICA Address: wf1gjj772jd2hf20dswpnwrkfetmpkndak8w5smud99dtzwrynrut0qnetxqc\n")
+	require.Contains(t, out, "Key:          "+expectedKey+"\n")
+	require.Contains(t, out, "Combined:     "+expectedCombined+"\n")
+	require.Contains(t, out, "Module Hash:  "+expectedModuleHash+"\n")
+	require.Contains(t, out, "Address Hash: "+expectedAddressHash+"\n")
+
+	out, err = runCLI(t, "--debug", "--json", testPrivateKey, "18", "08-wasm-0", "wf")
+	require.NoError(t, err)
+	var result output
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	require.Equal(t, &debugOutput{
+		Key:         expectedKey,
+		Combined:    expectedCombined,
+		ModuleHash:  expectedModuleHash,
+		AddressHash: expectedAddressHash,
+	}, result.Debug)
+
+	_, err = runCLI(t, "--debug", "--salts", "a", testPrivateKey, "18", "08-wasm-0", "wf")
+	require.ErrorIs(t, err, errDebugWithSalts)
+}
+
+func TestRunUsage(t *testing.T) {
+	_, err := runCLI(t, testPrivateKey, "18")
+	require.ErrorIs(t, err, errUsage)
+}
+
+func TestRunSubcommands(t *testing.T) {
+	const testICAAddress = "wf1gjj772jd2hf20dswpnwrkfetmpkndak8w5smud99dtzwrynrut0qnetxqc"
+	iftFlags := []string{"--private-key", testPrivateKey, "--nonce", "18"}
+	icaFlags := []string{"--private-key", testPrivateKey, "--nonce", "18", "--client-id", "08-wasm-0", "--bech32-prefix", "wf"}
+
+	t.Run("ift", func(t *testing.T) {
+		out, err := runCLI(t, append([]string{"ift"}, iftFlags...)...)
+		require.NoError(t, err)
+		require.Equal(t, "IFT Address: "+testIFTAddress+"\n", out)
+
+		out, err = runCLI(t, append([]string{"ift", "--json", "--address-format", "raw"}, iftFlags...)...)
+		require.NoError(t, err)
+		var result output
+		require.NoError(t, json.Unmarshal([]byte(out), &result))
+		require.Equal(t, output{IFTAddress: "68b1d87f95878fe05b998f19b66f4baba5de1aed"}, result)
+
+		_, err = runCLI(t, "ift", "--private-key", testPrivateKey)
+		require.ErrorIs(t, err, errMissingFlags)
+		require.ErrorContains(t, err, "--nonce")
+	})
+
+	t.Run("ica", func(t *testing.T) {
+		out, err := runCLI(t, append([]string{"ica"}, icaFlags...)...)
+		require.NoError(t, err)
+		require.Equal(t, "ICA Address: "+testICAAddress+"\n"+testContext, out)
+
+		out, err = runCLI(t, append([]string{"ica", "--salt", "mysalt", "--json"}, icaFlags...)...)
+		require.NoError(t, err)
+		var result output
+		require.NoError(t, json.Unmarshal([]byte(out), &result))
+		require.Equal(t, output{ICAAddress: "wf1ap6hg2kdxrrrar5d9qgnccdachk88kuf0z68hh042trjkrmpagjq4xsfee", Context: testICAContext("mysalt")}, result)
+
+		out, err = runCLI(t, append([]string{"ica", "--salts", "", "--salts", "mysalt"}, icaFlags...)...)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		require.Len(t, lines, 7)
+		require.Equal(t, "Client ID:     08-wasm-0", lines[0])
+		require.Equal(t, []string{"SALT", "ICA", "ADDRESS"}, strings.Fields(lines[4]))
+
+		_, err = runCLI(t, append([]string{"ica"}, iftFlags...)...)
+		require.ErrorIs(t, err, errMissingFlags)
+		require.ErrorContains(t, err, "--client-id, --bech32-prefix")
+	})
+
+	t.Run("both", func(t *testing.T) {
+		out, err := runCLI(t, append([]string{"both"}, icaFlags...)...)
+		require.NoError(t, err)
+		legacy, err := runCLI(t, testPrivateKey, "18", "08-wasm-0", "wf")
+		require.NoError(t, err)
+		require.Equal(t, legacy, out)
+		require.Equal(t, "IFT Address: "+testIFTAddress+"\nICA Address: "+testICAAddress+"\n"+testContext, out)
+
+		_, err = runCLI(t, "both", "--private-key", testPrivateKey)
+		require.ErrorIs(t, err, errMissingFlags)
+
+		_, err = runCLI(t, append([]string{"both", "extra"}, icaFlags...)...)
+		require.Error(t, err)
+	})
+
+	_, err := runCLI(t, "--nonce", "18", testPrivateKey, "18", "08-wasm-0", "wf")
+	require.ErrorIs(t, err, errPositionalWithFlags)
+}
+
+func TestRunContextJSON(t *testing.T) {
+	out, err := runCLI(t, "--json", "--address-format", "raw", testPrivateKey, "18", "08-wasm-0", "wf", "mysalt")
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &fields))
+	// The sender is the checksummed IFT address the ICA is derived from, whatever the printed format
+	require.Equal(t, map[string]any{
+		"clientId":     "08-wasm-0",
+		"sender":       testIFTAddress,
+		"salt":         "mysalt",
+		"bech32Prefix": "wf",
+	}, fields["context"])
+
+	out, err = runCLI(t, "--json", "--salts", "mysalt", testPrivateKey, "18", "08-wasm-0", "wf")
+	require.NoError(t, err)
+	var table output
+	require.NoError(t, json.Unmarshal([]byte(out), &table))
+	require.Equal(t, &icaContext{ClientID: "08-wasm-0", Sender: testIFTAddress, Bech32Prefix: "wf"}, table.Context)
+
+	out, err = runCLI(t, "ift", "--json", "--private-key", testPrivateKey, "--nonce", "18")
+	require.NoError(t, err)
+	require.NotContains(t, out, "context")
+}
+
+func TestRunNonceBounds(t *testing.T) {
+	testCases := []struct {
+		name    string
+		args    []string
+		warning bool
+		expErr  error
+	}{
+		{name: "zero warns", args: []string{testPrivateKey, "0", "08-wasm-0", "wf"}, warning: true},
+		{name: "zero with force", args: []string{"--force", testPrivateKey, "0", "08-wasm-0", "wf"}},
+		{name: "one", args: []string{testPrivateKey, "1", "08-wasm-0", "wf"}},
+		{name: "at threshold", args: []string{testPrivateKey, "1000000", "08-wasm-0", "wf"}},
+		{name: "above threshold", args: []string{testPrivateKey, "1000001", "08-wasm-0", "wf"}, expErr: errNonceTooLarge},
+		{name: "above threshold with force", args: []string{"--force", testPrivateKey, "1000001", "08-wasm-0", "wf"}},
+		{name: "max uint64 with force", args: []string{"--force", testPrivateKey, "18446744073709551615", "08-wasm-0", "wf"}},
+		{name: "subcommand above threshold", args: []string{"ift", "--private-key", testPrivateKey, "--nonce", "1000001"}, expErr: errNonceTooLarge},
+		{name: "subcommand zero warns", args: []string{"ift", "--private-key", testPrivateKey, "--nonce", "0"}, warning: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run(tc.args, &stdout, &stderr)
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				require.Empty(t, stdout.String())
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, stdout.String(), "IFT Address: ")
+
+			if tc.warning {
+				require.Contains(t, stderr.String(), "Warning: nonce 0")
+			} else {
+				require.NotContains(t, stderr.String(), "Warning: nonce 0")
+			}
+		})
+	}
+}
+
+func TestRunNetwork(t *testing.T) {
+	const testICAAddress = "wf1gjj772jd2hf20dswpnwrkfetmpkndak8w5smud99dtzwrynrut0qnetxqc"
+	icaFlags := []string{"--private-key", testPrivateKey, "--nonce", "18", "--client-id", "08-wasm-0"}
+
+	out, err := runCLI(t, append([]string{"ica", "--network", "wfchain"}, icaFlags...)...)
+	require.NoError(t, err)
+	require.Equal(t, "ICA Address: "+testICAAddress+"\n"+testContext, out)
+
+	out, err = runCLI(t, append([]string{"ica", "--network", "osmosis", "--json"}, icaFlags...)...)
+	require.NoError(t, err)
+	var result output
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	require.Equal(t, "osmo", result.Context.Bech32Prefix)
+	require.True(t, strings.HasPrefix(result.ICAAddress, "osmo1"))
+
+	// --bech32-prefix overrides the network's prefix
+	out, err = runCLI(t, append([]string{"ica", "--network", "osmosis", "--bech32-prefix", "wf"}, icaFlags...)...)
+	require.NoError(t, err)
+	require.Equal(t, "ICA Address: "+testICAAddress+"\n"+testContext, out)
+
+	_, err = runCLI(t, append([]string{"both", "--network", "mars"}, icaFlags...)...)
+	require.ErrorIs(t, err, errUnknownNetwork)
+	require.ErrorContains(t, err, `"mars"`)
+	require.ErrorContains(t, err, "cosmoshub, dydx")
+
+	_, err = runCLI(t, append([]string{"ica"}, icaFlags...)...)
+	require.ErrorIs(t, err, errMissingFlags)
+	require.ErrorContains(t, err, "--bech32-prefix (or --network)")
+
+	_, err = runCLI(t, "--network", "osmosis", testPrivateKey, "18", "08-wasm-0", "wf")
+	require.ErrorIs(t, err, errPositionalWithFlags)
+}
+
+func TestRunPrivateKeySources(t *testing.T) {
+	iftArgs := []string{"ift", "--nonce", "18"}
+	expected := "IFT Address: " + testIFTAddress + "\n"
+
+	t.Run("file", func(t *testing.T) {
+		keyFile := filepath.Join(t.TempDir(), "key")
+		require.NoError(t, os.WriteFile(keyFile, []byte("0x"+testPrivateKey+"\n"), 0o600))
+
+		out, err := runCLI(t, append(iftArgs, "--private-key-file", keyFile)...)
+		require.NoError(t, err)
+		require.Equal(t, expected, out)
+
+		_, err = runCLI(t, append(iftArgs, "--private-key-file", filepath.Join(t.TempDir(), "missing"))...)
+		require.ErrorContains(t, err, "reading private key file")
+
+		_, err = runCLI(t, append(iftArgs, "--private-key-file", keyFile, "--private-key", testPrivateKey)...)
+		require.ErrorContains(t, err, "none of the others can be")
+	})
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv(privateKeyEnv, testPrivateKey)
+
+		out, err := runCLI(t, iftArgs...)
+		require.NoError(t, err)
+		require.Equal(t, expected, out)
+
+		out, err = runCLI(t, "both", "--nonce", "18", "--client-id", "08-wasm-0", "--bech32-prefix", "wf")
+		require.NoError(t, err)
+		legacy, err := runCLI(t, testPrivateKey, "18", "08-wasm-0", "wf")
+		require.NoError(t, err)
+		require.Equal(t, legacy, out)
+
+		// The flag takes precedence over the environment
+		t.Setenv(privateKeyEnv, "not a key")
+		out, err = runCLI(t, append(iftArgs, "--private-key", testPrivateKey)...)
+		require.NoError(t, err)
+		require.Equal(t, expected, out)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Setenv(privateKeyEnv, "")
+
+		_, err := runCLI(t, iftArgs...)
+		require.ErrorIs(t, err, errMissingFlags)
+		require.ErrorContains(t, err, "--private-key (or --private-key-file, PRIVATE_KEY)")
+	})
+
+	t.Run("positional is deprecated", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		require.NoError(t, run([]string{testPrivateKey, "18", "08-wasm-0", "wf"}, &stdout, &stderr))
+		require.Contains(t, stderr.String(), "Warning: the positional form is deprecated")
+
+		stderr.Reset()
+		require.NoError(t, run(append(iftArgs, "--private-key", testPrivateKey), &stdout, &stderr))
+		require.Empty(t, stderr.String())
+	})
+}
+
+func TestRunSolanaGMPAccount(t *testing.T) {
+	const (
+		testICAAddress = "wf1gjj772jd2hf20dswpnwrkfetmpkndak8w5smud99dtzwrynrut0qnetxqc"
+		programID      = "3W3h4WSE8J9vFzVN8TGFGc9Uchbry3M4MBz4icdSWcFi"
+	)
+	icaFlags := []string{"--private-key", testPrivateKey, "--nonce", "18", "--client-id", "08-wasm-0", "--bech32-prefix", "wf", "--solana-gmp-program", programID}
+
+	out, err := runCLI(t, append([]string{"both"}, icaFlags...)...)
+	require.NoError(t, err)
+	require.Equal(t, "IFT Address: "+testIFTAddress+"\nICA Address: "+testICAAddress+
+		"\nSolana GMP Account: E2wzuKASFLYJwUWx8zAebyUjMAx65krDcEWW99iUjfmK (program "+programID+", bump 255)\n"+testContext, out)
+
+	out, err = runCLI(t, append([]string{"ica", "--json", "--salt", "mysalt"}, icaFlags...)...)
+	require.NoError(t, err)
+	var result output
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	require.Equal(t, output{
+		ICAAddress:       "wf1ap6hg2kdxrrrar5d9qgnccdachk88kuf0z68hh042trjkrmpagjq4xsfee",
+		SolanaGMPAccount: &solanaGMPAccount{ProgramID: programID, Address: "8v2RPnbw8V4dxyHS7oj1iSJwKKV5SryNpiubtVDgFxMJ", Bump: 254},
+		Context:          testICAContext("mysalt"),
+	}, result)
+
+	_, err = runCLI(t, append([]string{"ica", "--salts", "a"}, icaFlags...)...)
+	require.ErrorIs(t, err, errSolanaWithSalts)
+
+	_, err = runCLI(t, "ica", "--private-key", testPrivateKey, "--nonce", "18", "--client-id", "08-wasm-0", "--bech32-prefix", "wf", "--solana-gmp-program", "0xabc")
+	require.ErrorIs(t, err, derivation.ErrInvalidSolanaProgramID)
+}
+
+func TestRunSelfTest(t *testing.T) {
+	out, err := runCLI(t, "selftest")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 8)
+	require.Equal(t, "ok   ift 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266 nonce 18: "+testIFTAddress, lines[2])
+	require.Equal(t, `ok   ica 08-wasm-0/`+testIFTAddress+` salt "": wf1gjj772jd2hf20dswpnwrkfetmpkndak8w5smud99dtzwrynrut0qnetxqc`, lines[3])
+	require.Equal(t, "All 7 vectors match", lines[7])
+
+	// A mismatching or underivable vector fails the run, without stopping at the first failure
+	vectors, err := loadSelfTestVectors()
+	require.NoError(t, err)
+	vectors.IFT[0].Address = testIFTAddress
+	vectors.ICA[1].Bech32Prefix = ""
+
+	var buf strings.Builder
+	err = runSelfTest(&buf, vectors)
+	require.ErrorIs(t, err, errSelfTestFailed)
+	require.ErrorContains(t, err, "2 of 7 vectors do not match")
+	require.Contains(t, buf.String(), "FAIL ift 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266 nonce 0: expected "+testIFTAddress+", got 0x5FbDB2315678afecb367f032d93F642f64180aa3\n")
+	require.Contains(t, buf.String(), `FAIL ica 08-wasm-0/`+testIFTAddress+` salt "mysalt": `)
+	require.Contains(t, buf.String(), "ok   ica 08-wasm-0/"+testIFTAddress)
+}